@@ -27,6 +27,14 @@ type WebAPIConfiguration struct {
 	DB    struct {
 		Filename string `conf:"default:/tmp/wasaphoto.db"`
 	}
+	Moderation struct {
+		Admins          []uint64
+		ReportThreshold uint64 `conf:"default:5"`
+	}
+	Usernames struct {
+		Cooldown       time.Duration `conf:"default:720h"`
+		ChangeInterval time.Duration `conf:"default:168h"`
+	}
 }
 
 // loadConfiguration creates a WebAPIConfiguration starting from flags, environment variables and configuration file.
@@ -129,8 +129,13 @@ func run() error {
 
 	// Create the API router
 	apirouter, err := api.New(api.Config{
-		Logger:   logger,
-		Database: db,
+		Logger:          logger,
+		Database:        db,
+		Admins:          cfg.Moderation.Admins,
+		ReportThreshold: cfg.Moderation.ReportThreshold,
+
+		UsernameCooldown:       cfg.Usernames.Cooldown,
+		UsernameChangeInterval: cfg.Usernames.ChangeInterval,
 	})
 	if err != nil {
 		logger.WithError(err).Error("error creating the API server instance")
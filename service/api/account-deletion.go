@@ -0,0 +1,105 @@
+package api
+
+import (
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"time"
+)
+
+const (
+	// BackgroundDeletionPosts is the number of posts from which an account is deleted in background
+	BackgroundDeletionPosts = 1000
+	// DeletionInterval is how often the deletion worker looks for the accounts waiting to be deleted
+	DeletionInterval = time.Minute
+)
+
+// startDeletionWorker starts the background goroutine that deletes the accounts with StatusDeleting, when one is
+// queued by queueUserDeletion and every DeletionInterval. The accounts queued before a restart are deleted at start.
+// The goroutine is stopped by Close.
+func (rt *_router) startDeletionWorker() {
+	rt.deletionStop = make(chan struct{})
+	rt.deletionDone = make(chan struct{})
+	rt.deletionWake = make(chan struct{}, 1)
+
+	go func() {
+		defer close(rt.deletionDone)
+
+		ticker := time.NewTicker(DeletionInterval)
+		defer ticker.Stop()
+
+		for {
+			rt.deletePendingUsers()
+
+			select {
+			case <-rt.deletionStop:
+				return
+			case <-ticker.C:
+			case <-rt.deletionWake:
+			}
+		}
+	}()
+}
+
+// stopDeletionWorker stops the goroutine started by startDeletionWorker and waits for it to exit.
+func (rt *_router) stopDeletionWorker() {
+	close(rt.deletionStop)
+	<-rt.deletionDone
+}
+
+// queueUserDeletion marks the account as deleting, so that it vanishes at once, and wakes up the deletion worker.
+func (rt *_router) queueUserDeletion(uid uint64) error {
+	err := rt.db.SetUserStatus(uid, database.StatusDeleting, "", time.Time{})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case rt.deletionWake <- struct{}{}:
+	default:
+		// the worker has already been woken up
+	}
+	return nil
+}
+
+// deletePendingUsers deletes the accounts with StatusDeleting. Errors are logged, and the account is tried again at
+// the next run.
+func (rt *_router) deletePendingUsers() {
+	uids, err := rt.db.GetUsersByStatus(database.StatusDeleting)
+	if err != nil {
+		rt.baseLogger.WithError(err).Error("error retrieving accounts to delete")
+		return
+	}
+
+	for _, uid := range uids {
+		err = rt.removeUser(uid)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error deleting account %d", uid)
+			continue
+		}
+		rt.baseLogger.Infof("account %d deleted", uid)
+	}
+}
+
+// removeUser deletes the account of the user uid, leaving a tombstone, and then removes the images of his posts and
+// stories. The images that cannot be removed are logged, as the account is already deleted.
+// Function will return nil if no errors are present, an error otherwise.
+func (rt *_router) removeUser(uid uint64) error {
+	media, stories, err := rt.db.DeleteUser(uid, globaltime.Now())
+	if err != nil {
+		return err
+	}
+	rt.suggestions.invalidate(uid)
+
+	err = deleteMediaImages(media)
+	if err != nil {
+		rt.baseLogger.WithError(err).Errorf("error removing images of account %d", uid)
+	}
+
+	for _, story := range stories {
+		err = deleteStoryImage(story)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error removing image of story %d", story.Storyid)
+		}
+	}
+	return nil
+}
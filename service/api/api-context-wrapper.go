@@ -1,12 +1,15 @@
 package api
 
 import (
+	"encoding/json"
 	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
 	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/sirupsen/logrus"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 // httpRouterHandler is the signature for functions that accepts a reqcontext.RequestContext in addition to those
@@ -14,6 +17,8 @@ import (
 type httpRouterHandler func(http.ResponseWriter, *http.Request, httprouter.Params, reqcontext.RequestContext)
 
 // wrap parses the request and adds a reqcontext.RequestContext instance related to the request.
+// For the APIs that require the bearer token, the role of the user is added to the context and suspended (or
+// deactivated, or deleted) users are rejected. The "/admin/" routes are allowed only to moderators and administrators.
 func (rt *_router) wrap(fn httpRouterHandler, auth bool) func(http.ResponseWriter, *http.Request, httprouter.Params) {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		reqUUID, err := uuid.NewV4()
@@ -45,6 +50,51 @@ func (rt *_router) wrap(fn httpRouterHandler, auth bool) func(http.ResponseWrite
 		var ctx = reqcontext.RequestContext{
 			ReqUUID: reqUUID,
 			Uid:     uint64(authId),
+			Role:    database.RoleUser,
+		}
+
+		if auth {
+			role, status, err := rt.getUserAccess(ctx.Uid)
+			if err != nil {
+				rt.baseLogger.WithError(err).Error("can't retrieve the role and the status of the user")
+				http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+				return
+			}
+			ctx.Role = role
+
+			if status.Status == database.StatusSuspended {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+
+				response := map[string]string{
+					"error":  "your account is suspended",
+					"reason": status.Reason,
+				}
+				if status.Until != "" {
+					response["until"], _ = formatDatetime(status.Until)
+				}
+
+				_ = json.NewEncoder(w).Encode(response)
+				return
+			}
+
+			if status.Status == database.StatusDeleting || status.Status == database.StatusDeleted {
+				rt.baseLogger.Error("The user is deleted!")
+				http.Error(w, "Your account has been deleted", http.StatusUnauthorized)
+				return
+			}
+
+			if status.Status == database.StatusDeactivated {
+				rt.baseLogger.Error("The user is deactivated!")
+				http.Error(w, "Your account is deactivated, log in to reactivate it", http.StatusForbidden)
+				return
+			}
+
+			if strings.HasPrefix(r.URL.Path, "/admin/") && !canModerate(ctx.Role) {
+				rt.baseLogger.Error("The user is not a moderator for an admin API!")
+				http.Error(w, "You are not a moderator", http.StatusForbidden)
+				return
+			}
 		}
 
 		// Create a request-specific logger
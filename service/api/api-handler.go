@@ -16,6 +16,17 @@ func (rt *_router) Handler() http.Handler {
 	rt.router.GET("/users/", rt.wrap(rt.getUsers, true))
 	rt.router.GET("/users/:uid/username", rt.wrap(rt.getUsername, true))
 	rt.router.PUT("/users/:uid/username", rt.wrap(rt.setUsername, true))
+	rt.static.GET("/users/by-name/:username", rt.wrap(rt.getUserByName, true))
+
+	/* ======== ACCOUNT API ========= */
+	rt.router.PUT("/users/:uid/deactivation", rt.wrap(rt.deactivateUser, true))
+	rt.router.DELETE("/users/:uid", rt.wrap(rt.deleteUser, true))
+
+	/* ======== EXPORT API ========= */
+	rt.router.POST("/users/:uid/export", rt.wrap(rt.requestExport, true))
+	rt.router.GET("/users/:uid/export", rt.wrap(rt.getExport, true))
+	rt.router.GET("/exports/:token", rt.wrap(rt.downloadExport, false))
+	rt.router.POST("/users/:uid/import", rt.wrap(rt.importPosts, true))
 
 	/* ======== FOLLOW API ========= */
 	rt.router.GET("/users/:uid/following/:fuid", rt.wrap(rt.getFollowing, true))
@@ -38,17 +49,110 @@ func (rt *_router) Handler() http.Handler {
 	rt.router.PUT("/posts/:postid/likes/:uid", rt.wrap(rt.likePost, true))
 	rt.router.DELETE("/posts/:postid/likes/:uid", rt.wrap(rt.unlikePost, true))
 
+	/* Section REACTION (a like is the "heart" reaction) */
+	rt.router.GET("/posts/:postid/reactions/:uid", rt.wrap(rt.getReaction, true))
+	rt.router.PUT("/posts/:postid/reactions/:uid", rt.wrap(rt.setReaction, true))
+	rt.router.DELETE("/posts/:postid/reactions/:uid", rt.wrap(rt.removeReaction, true))
+	rt.router.GET("/posts/:postid/comments/:commentid/reactions/:uid", rt.wrap(rt.getReaction, true))
+	rt.router.PUT("/posts/:postid/comments/:commentid/reactions/:uid", rt.wrap(rt.setReaction, true))
+	rt.router.DELETE("/posts/:postid/comments/:commentid/reactions/:uid", rt.wrap(rt.removeReaction, true))
+
+	/* Section CAPTION */
+	rt.router.PUT("/users/:uid/posts/:postid/caption", rt.wrap(rt.setPostCaption, true))
+
+	/* Section DRAFTS */
+	rt.router.PUT("/users/:uid/posts/:postid/publication", rt.wrap(rt.setPostPublication, true))
+	rt.router.GET("/users/:uid/drafts", rt.wrap(rt.getDraftPosts, true))
+
+	/* Section PINNED */
+	rt.router.PUT("/users/:uid/pinned/:postid", rt.wrap(rt.pinPost, true))
+	rt.router.DELETE("/users/:uid/pinned/:postid", rt.wrap(rt.unpinPost, true))
+
+	/* Section ARCHIVE */
+	rt.router.GET("/users/:uid/archive", rt.wrap(rt.getArchivedPosts, true))
+	rt.router.PUT("/users/:uid/archive/:postid", rt.wrap(rt.archivePost, true))
+	rt.router.DELETE("/users/:uid/archive/:postid", rt.wrap(rt.unarchivePost, true))
+
 	/* Section COMMENT */
 	rt.router.POST("/posts/:postid/comments/", rt.wrap(rt.commentPost, true))
 	rt.router.DELETE("/posts/:postid/comments/:commentid", rt.wrap(rt.uncommentPost, true))
+	rt.router.PUT("/posts/:postid/comments/:commentid/likes/:uid", rt.wrap(rt.likeComment, true))
+	rt.router.DELETE("/posts/:postid/comments/:commentid/likes/:uid", rt.wrap(rt.unlikeComment, true))
+
+	/* Section REPORT */
+	rt.router.POST("/posts/:postid/reports", rt.wrap(rt.reportContent, true))
+	rt.router.POST("/posts/:postid/comments/:commentid/reports", rt.wrap(rt.reportContent, true))
+
+	/* ======== SAVED API ========= */
+	rt.router.GET("/users/:uid/saved", rt.wrap(rt.getSavedPosts, true))
+	rt.router.PUT("/users/:uid/saved/:postid", rt.wrap(rt.savePost, true))
+	rt.router.DELETE("/users/:uid/saved/:postid", rt.wrap(rt.unsavePost, true))
+
+	/* Section REPOST */
+	rt.router.PUT("/users/:uid/reposts/:postid", rt.wrap(rt.repostPost, true))
+	rt.router.DELETE("/users/:uid/reposts/:postid", rt.wrap(rt.unrepostPost, true))
+
+	/* Section COLLECTION */
+	rt.router.GET("/users/:uid/collections/", rt.wrap(rt.getCollections, true))
+	rt.router.POST("/users/:uid/collections/", rt.wrap(rt.createCollection, true))
+	rt.router.PUT("/users/:uid/collections/:collectionid", rt.wrap(rt.renameCollection, true))
+	rt.router.DELETE("/users/:uid/collections/:collectionid", rt.wrap(rt.deleteCollection, true))
+
+	/* ======== TAGS API ========= */
+	rt.router.GET("/tags/:tag/posts", rt.wrap(rt.getTagPosts, true))
+	rt.static.GET("/tags/trending", rt.wrap(rt.getTrendingTags, true))
+
+	/* ======== SEARCH API ========= */
+	rt.router.GET("/search/posts", rt.wrap(rt.searchPosts, true))
 
 	/* ======== MYSTREAM API ========= */
 	rt.router.GET("/users/:uid/mystream", rt.wrap(rt.getMyStream, true))
 
+	/* ======== STORIES API ========= */
+	rt.router.GET("/users/:uid/stories", rt.wrap(rt.getUserStories, true))
+	rt.router.POST("/users/:uid/stories", rt.wrap(rt.uploadStory, true))
+	rt.router.GET("/users/:uid/mystream/stories", rt.wrap(rt.getStreamStories, true))
+	rt.router.GET("/stories/:storyid/image", rt.wrap(rt.getStoryImage, true))
+	rt.router.GET("/stories/:storyid/views", rt.wrap(rt.getStoryViewers, true))
+	rt.router.PUT("/stories/:storyid/views/:uid", rt.wrap(rt.seeStory, true))
+
+	/* ======== EXPLORE API ========= */
+	rt.router.GET("/users/:uid/explore", rt.wrap(rt.getExplore, true))
+
+	/* ======== SUGGESTIONS API ========= */
+	rt.router.GET("/users/:uid/suggestions", rt.wrap(rt.getSuggestions, true))
+
+	/* ======== NOTIFICATIONS API ========= */
+	rt.router.GET("/users/:uid/notifications", rt.wrap(rt.getNotifications, true))
+
 	/* ======== PROFILE API ========= */
 	rt.router.GET("/users/:uid/profile", rt.wrap(rt.getUserProfile, true))
 
+	/* ======== MODERATION API (guarded in wrap) ========= */
+	rt.router.GET("/admin/reports", rt.wrap(rt.getReports, true))
+	rt.router.POST("/admin/reports/:reportid/resolve", rt.wrap(rt.resolveReport, true))
+	rt.router.POST("/admin/reports/:reportid/dismiss", rt.wrap(rt.dismissReport, true))
+	rt.router.GET("/admin/audit", rt.wrap(rt.getModerationActions, true))
+	rt.router.DELETE("/admin/posts/:postid", rt.wrap(rt.removeAnyPost, true))
+	rt.router.DELETE("/admin/comments/:commentid", rt.wrap(rt.removeAnyComment, true))
+
+	/* ======== ADMIN API (guarded in wrap) ========= */
+	rt.router.GET("/admin/stats", rt.wrap(rt.getStats, true))
+	rt.router.GET("/admin/users/:uid", rt.wrap(rt.getUserAccount, true))
+	rt.router.PUT("/admin/users/:uid/role", rt.wrap(rt.setUserRole, true))
+	rt.router.PUT("/admin/users/:uid/username", rt.wrap(rt.forceUsername, true))
+	rt.router.PUT("/admin/users/:uid/suspension", rt.wrap(rt.suspendUser, true))
+	rt.router.DELETE("/admin/users/:uid/suspension", rt.wrap(rt.unsuspendUser, true))
+
 	/* ======== SPECIAL ROUTES ========= */
 	rt.router.GET("/liveness", rt.liveness)
-	return rt.router
+
+	// Static routes have precedence over the wildcard ones
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handle, _, _ := rt.static.Lookup(r.Method, r.URL.Path); handle != nil {
+			rt.static.ServeHTTP(w, r)
+			return
+		}
+		rt.router.ServeHTTP(w, r)
+	})
 }
@@ -42,6 +42,7 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/sirupsen/logrus"
 	"net/http"
+	"time"
 )
 
 // Config is used to provide dependencies and configuration to the New function.
@@ -51,6 +52,19 @@ type Config struct {
 
 	// Database is the instance of database.AppDatabase where data are saved
 	Database database.AppDatabase
+
+	// Admins are the uids of the users that are bootstrapped as administrators
+	Admins []uint64
+
+	// ReportThreshold is the number of open reports that hides a post or a comment until a moderator reviews it
+	// (0 disables the automatic hiding)
+	ReportThreshold uint64
+
+	// UsernameCooldown is how long a username left by a user cannot be taken by another user (0 disables it)
+	UsernameCooldown time.Duration
+
+	// UsernameChangeInterval is the minimum time between two changes of the username of a user (0 disables it)
+	UsernameChangeInterval time.Duration
 }
 
 // Router is the package API interface representing an API handler builder
@@ -78,19 +92,90 @@ func New(cfg Config) (Router, error) {
 	router.RedirectTrailingSlash = false
 	router.RedirectFixedPath = false
 
-	return &_router{
-		router:     router,
-		baseLogger: cfg.Logger,
-		db:         cfg.Database,
-	}, nil
+	// httprouter doesn't allow a static path segment where a wildcard is registered (e.g., "/tags/trending" and
+	// "/tags/:tag/posts"), so these static routes are registered in a separate router which has precedence.
+	static := httprouter.New()
+	static.RedirectTrailingSlash = false
+	static.RedirectFixedPath = false
+
+	rt := &_router{
+		router:                 router,
+		static:                 static,
+		baseLogger:             cfg.Logger,
+		db:                     cfg.Database,
+		suggestions:            newSuggestionsCache(),
+		admins:                 cfg.Admins,
+		threshold:              cfg.ReportThreshold,
+		usernameCooldown:       cfg.UsernameCooldown,
+		usernameChangeInterval: cfg.UsernameChangeInterval,
+	}
+
+	// Images uploaded before carousel support have no format and size in the database
+	rt.completeMediaInfo()
+
+	// Administrators in the configuration have the administrator role
+	rt.bootstrapAdmins()
+
+	// Usernames that differ only by the case from an older one must be changed
+	rt.reportUsernameCollisions()
+
+	// Expired stories are removed in background until Close
+	rt.startStoryReaper()
+
+	// Accounts with a lot of posts (and the ones left by a previous run) are deleted in background until Close
+	rt.startDeletionWorker()
+
+	// Exports of the personal data are built (and removed when they expire) in background until Close
+	rt.startExportWorker()
+
+	// Scheduled posts are published in background until Close
+	rt.startPostScheduler()
+
+	return rt, nil
 }
 
 type _router struct {
 	router *httprouter.Router
 
+	// static is the router for static routes that conflict with wildcard routes in router
+	static *httprouter.Router
+
 	// baseLogger is a logger for non-requests contexts, like goroutines or background tasks not started by a request.
 	// Use context logger if available (e.g., in requests) instead of this logger.
 	baseLogger logrus.FieldLogger
 
 	db database.AppDatabase
+
+	// suggestions caches the follow suggestions of the users
+	suggestions *suggestionsCache
+
+	// admins are the uids of the administrators in the configuration, and threshold the number of reports that hides a
+	// content
+	admins    []uint64
+	threshold uint64
+
+	// usernameCooldown is how long a username left by a user is reserved, and usernameChangeInterval the minimum time
+	// between two changes of the username of a user
+	usernameCooldown       time.Duration
+	usernameChangeInterval time.Duration
+
+	// reaperStop is closed to stop the story reaper, which closes reaperDone when it exits
+	reaperStop chan struct{}
+	reaperDone chan struct{}
+
+	// deletionStop is closed to stop the deletion worker, which closes deletionDone when it exits; deletionWake wakes
+	// it up when an account is queued
+	deletionStop chan struct{}
+	deletionDone chan struct{}
+	deletionWake chan struct{}
+
+	// exportStop is closed to stop the export worker, which closes exportDone when it exits; exportWake wakes it up
+	// when an export is queued
+	exportStop chan struct{}
+	exportDone chan struct{}
+	exportWake chan struct{}
+
+	// schedulerStop is closed to stop the post scheduler, which closes schedulerDone when it exits
+	schedulerStop chan struct{}
+	schedulerDone chan struct{}
 }
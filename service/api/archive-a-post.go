@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// archivePost allows a user to archive one of his published posts without deleting it: an archived post is not shown
+// in the profile, in the streams and by getPost to the other users, who cannot interact with it either (see
+// canSeePost), and it's removed from the pinned posts. Its likes and comments are kept, and the post can be restored by
+// unarchivePost. Archiving a post already archived has no effect.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the post id doesn't exist, the request will fail.
+// If the user is not the post author, the request will fail.
+// If the post is not published, the request will fail.
+func (rt *_router) archivePost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in archiving post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in archiving post request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes archiving post request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for archiving post request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in archiving post request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in archiving post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for archiving post!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in archiving post request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// check if the user is the post author
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in archiving post request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if postDB.Uid != uid {
+		context.Logger.Error("User is not the owner of the post in archiving post request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// drafts and scheduled posts cannot be archived
+	if postDB.Status != database.PostPublished {
+		context.Logger.Error("Error in archiving post request! Post is not published")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "the post is not published",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	err = rt.db.SetPostArchived(postid, true)
+	if err != nil {
+		context.Logger.Error("Error archiving post.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong archiving the post.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
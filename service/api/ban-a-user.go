@@ -132,6 +132,7 @@ func (rt *_router) banUser(w http.ResponseWriter, r *http.Request, params httpro
 			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
 			return
 		}
+		rt.suggestions.invalidate(uid, muteduid)
 	}
 
 	w.WriteHeader(http.StatusCreated)
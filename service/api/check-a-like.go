@@ -8,7 +8,7 @@ import (
 	"strconv"
 )
 
-// getLike allows to check if a user has put like to a post.
+// getLike allows to check if a user has put like (the "heart" reaction) to a post.
 // If the user in not authorized, the request will fail.
 // If the user id doesn't exist, the request will fail.
 // If the post id doesn't exist, the request will fail.
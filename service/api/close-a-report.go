@@ -0,0 +1,133 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// closeReport closes an open report, together with all the open reports of the same content, with a moderation
+// action: ModerationResolve hides the content, while ModerationDismiss shows it again. The action is written in the
+// audit trail.
+// If the user is not authorized or he is not a moderator, the request will fail.
+// If the report id doesn't exist, the request will fail.
+// If the report is already closed, the request will fail.
+// If the request is OK, it will return the closed Report{} object.
+func (rt *_router) closeReport(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext, action string) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in closing report request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is a moderator
+	if !canModerate(context.Role) {
+		context.Logger.Error("The user that makes closing report request is not a moderator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The Report ID in the path is a 64-bit unsigned integer. Let's parse it.
+	reportid, err := strconv.ParseUint(params.ByName("reportid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing reportid in closing report request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for reportid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	reportDB, err := rt.db.GetReport(reportid)
+	if errors.Is(err, sql.ErrNoRows) {
+		context.Logger.Error("Error in closing report request! Report doesn't exist")
+		http.Error(w, "Report seems not exist.", http.StatusNotFound)
+		return
+	} else if err != nil {
+		context.Logger.Error("Error retrieving report in closing report request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if reportDB.Status != database.ReportOpen {
+		context.Logger.Error("Error in closing report request! Report already closed")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "the report is already " + reportDB.Status,
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	status := database.ReportResolved
+	if action == ModerationDismiss {
+		status = database.ReportDismissed
+	}
+
+	err = rt.db.SetContentHidden(reportDB.Postid, reportDB.Commentid, action == ModerationResolve)
+	if err != nil {
+		context.Logger.Error("Error changing content visibility in closing report request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong closing the report.", http.StatusInternalServerError)
+		return
+	}
+
+	err = rt.db.SetReportsStatus(reportDB.Postid, reportDB.Commentid, status)
+	if err != nil {
+		context.Logger.Error("Error changing report status in closing report request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong closing the report.", http.StatusInternalServerError)
+		return
+	}
+
+	err = rt.db.AddModerationAction(database.ModerationAction{
+		Actor:     context.Uid,
+		Action:    action,
+		Reportid:  reportDB.Reportid,
+		Postid:    reportDB.Postid,
+		Commentid: reportDB.Commentid,
+		Detail:    reportDB.Reason,
+	})
+	if err != nil {
+		context.Logger.Error("Error writing audit trail in closing report request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong closing the report.", http.StatusInternalServerError)
+		return
+	}
+
+	reportDB, err = rt.db.GetReport(reportid)
+	if err != nil {
+		context.Logger.Error("Error retrieving report in closing report request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong closing the report.", http.StatusInternalServerError)
+		return
+	}
+
+	var report Report
+	err = report.FromDatabase(reportDB)
+	if err != nil {
+		context.Logger.Error("Error parsing report structure in closing report request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong closing the report.", http.StatusInternalServerError)
+		return
+	}
+	report.Datetime, _ = formatDatetime(report.Datetime)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Report{"report": report})
+}
@@ -14,6 +14,8 @@ import (
 // If the user in not authorized, the request will fail.
 // If the user id doesn't exist, the request will fail.
 // If the post id doesn't exist, the request will fail.
+// If the post owner has banned the user, the request will fail.
+// If the user cannot see the post (see canSeePost), the request will fail.
 // If the request is OK, it will return Comment{} object.
 func (rt *_router) commentPost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
 
@@ -139,18 +141,41 @@ func (rt *_router) commentPost(w http.ResponseWriter, r *http.Request, params ht
 		return
 	}
 
+	// check if the post is visible to the user
+	visible, err := rt.canSeePost(postDB, uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility in adding comment request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Post is not visible to the user in adding comment request!")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
 	// Check message validity
+	commentApi.Message = normalizeText(commentApi.Message)
 	if !commentApi.IsValid() {
 		context.Logger.Error("Content message for comment is not valid!")
 		http.Error(w, "Your message cannot be uploaded. Check its format!", http.StatusBadRequest)
 		return
 	}
 
+	// Resolve the mentioned users
+	mentions, err := rt.resolveMentions(commentApi.Message)
+	if err != nil {
+		context.Logger.Error("Error resolving mentions in adding comment request\nDetail: ", err.Error())
+		http.Error(w, "Something wrong uploading your comment", http.StatusInternalServerError)
+		return
+	}
+
 	// Insert comment into table
 	commentApi.Postid = postid
 	var commentDb database.Comment
 	commentDb = commentApi.ToDatabase()
-	commentDb, err = rt.db.AddComment(commentDb.Userid, commentDb.Postid, commentDb.Message)
+	commentDb, err = rt.db.AddComment(commentDb.Userid, commentDb.Postid, commentDb.Message, extractHashtags(commentDb.Message), mentions)
 
 	if err != nil {
 		context.Logger.Error("Error inserting comment into tables\nDetail: ", err.Error())
@@ -158,6 +183,9 @@ func (rt *_router) commentPost(w http.ResponseWriter, r *http.Request, params ht
 		return
 	}
 
+	// Notify the mentioned users
+	rt.notifyMentions(commentDb, postDB, context)
+
 	// Message correctly inserted
 	err = commentApi.FromDatabase(commentDb)
 
@@ -0,0 +1,23 @@
+package api
+
+// markLikedComments sets LikedByMe on the comments of the post that uid has liked. It has to be called after
+// Post.FromDatabase, as the database package doesn't know who is making the request.
+func (rt *_router) markLikedComments(post *Post, uid uint64) error {
+	if len(post.Comments) == 0 {
+		return nil
+	}
+
+	liked, err := rt.db.GetLikedComments(post.Postid, uid)
+	if err != nil {
+		return err
+	}
+
+	likedSet := make(map[uint64]bool, len(liked))
+	for _, commentid := range liked {
+		likedSet[commentid] = true
+	}
+	for i := range post.Comments {
+		post.Comments[i].LikedByMe = likedSet[post.Comments[i].Commentid]
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// createCollection allows a user to create a new named collection for his saved posts.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the name is not valid, the request will fail.
+// If the user already has a collection with the same name, the request will fail.
+// If the request is OK, it will return the created Collection{} object.
+func (rt *_router) createCollection(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in creating collection request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in creating collection request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes creating collection request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for creating collection request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in creating collection request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	var collection Collection
+	err = json.NewDecoder(r.Body).Decode(&collection)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	collection.Name = normalizeText(strings.TrimSpace(collection.Name))
+	if !collection.IsValid() {
+		context.Logger.Error("Name for collection is not valid!")
+		http.Error(w, "Your collection cannot be created. Check its name!", http.StatusBadRequest)
+		return
+	}
+
+	// check if the user already has a collection with the same name
+	check, err = rt.db.CheckExistsCollectionName(uid, collection.Name)
+	if err != nil {
+		context.Logger.Error("Error retrieving collection information in creating collection request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if check {
+		context.Logger.Error("Error in creating collection request! Name already used")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "you already have a collection with this name",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	collectionDB, err := rt.db.CreateCollection(uid, collection.Name)
+	if err != nil {
+		context.Logger.Error("Error creating collection.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong creating your collection.", http.StatusInternalServerError)
+		return
+	}
+
+	err = collection.FromDatabase(collectionDB)
+	if err != nil {
+		context.Logger.Error("Error parsing collection structure in creating collection request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong creating your collection.", http.StatusInternalServerError)
+		return
+	}
+	collection.Datetime, _ = formatDatetime(collection.Datetime)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]Collection{"collection": collection})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// deactivateUser allows a user to deactivate his account. A deactivated user vanishes from the search, the streams
+// and the follower counts, and he cannot use the API until he logs in again: then his account is reactivated intact.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+func (rt *_router) deactivateUser(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in deactivating account request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in deactivating account request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes deactivating account request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for deactivating account request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in deactivating account request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	err = rt.db.SetUserStatus(uid, database.StatusDeactivated, "", time.Time{})
+	if err != nil {
+		context.Logger.Error("Error deactivating account.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong deactivating your account.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -94,40 +94,10 @@ func (rt *_router) deletePost(w http.ResponseWriter, r *http.Request, params htt
 		return
 	}
 
-	// Remove all comments under the post
-	err = rt.db.RemoveCommentsFromPost(postid)
+	// Remove the post with its comments, hashtags, reactions and images
+	err = rt.removePost(postid, uid)
 	if err != nil {
-		context.Logger.Info("Error removing comments under post\nDetail: ", err.Error())
-		http.Error(w, "Something wrong deleting the post", http.StatusInternalServerError)
-		return
-	}
-
-	// Remove all likes from the post
-	err = rt.db.RemoveLikesFromPost(postid)
-	if err != nil {
-		context.Logger.Info("Error removing likes under post\nDetail: ", err.Error())
-		http.Error(w, "Something wrong deleting the post", http.StatusInternalServerError)
-		return
-	}
-
-	// Remove the image from folder
-	// First try with .png, if not exists try with .jpeg
-	pngPath := strconv.FormatUint(postid, 10) + ".png"
-	err = deleteImage("media/img/", pngPath)
-	if err != nil {
-		jpegPath := strconv.FormatUint(postid, 10) + ".jpeg"
-		err = deleteImage("media/img/", jpegPath)
-		if err != nil {
-			context.Logger.Error("Error removing post!\nDetail: ", err.Error())
-			http.Error(w, "Something wrong removing post", http.StatusInternalServerError)
-			return
-		}
-	}
-
-	// Remove post from table
-	err = rt.db.RemovePost(postid, uid)
-	if err != nil {
-		context.Logger.Error("Cannot delete post from table.\nDetail: ", err.Error())
+		context.Logger.Error("Error removing post!\nDetail: ", err.Error())
 		http.Error(w, "Something wrong removing post", http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// deleteUser allows a user to delete his account, together with his posts (and their images), his comments and
+// reactions on the other posts, his follows, bans and stories. The user becomes a tombstone, so what still references
+// him (e.g., mentions) renders as a deleted user, and his username can be used by a new user.
+// Accounts with at least BackgroundDeletionPosts posts vanish at once and are deleted in background (202 is returned).
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+func (rt *_router) deleteUser(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in deleting account request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in deleting account request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes deleting account request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for deleting account request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in deleting account request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	posts, err := rt.db.CountPosts(uid)
+	if err != nil {
+		context.Logger.Error("Error counting posts in deleting account request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	// large accounts are deleted in background
+	if posts >= BackgroundDeletionPosts {
+		err = rt.queueUserDeletion(uid)
+		if err != nil {
+			context.Logger.Error("Error queuing account deletion.\nDetail: ", err.Error())
+			http.Error(w, "Something wrong deleting your account.", http.StatusInternalServerError)
+			return
+		}
+
+		context.Logger.Infof("Deletion of account %d queued (%d posts)", uid, posts)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	err = rt.removeUser(uid)
+	if err != nil {
+		context.Logger.Error("Error deleting account.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong deleting your account.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// deleteCollection allows a user to delete one of his collections of saved posts. The posts in the collection stay
+// saved, without a collection.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the collection id doesn't exist or the collection is owned by another user, the request will fail.
+func (rt *_router) deleteCollection(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in deleting collection request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in deleting collection request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes deleting collection request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for deleting collection request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in deleting collection request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Collection ID in the path is a 64-bit unsigned integer. Let's parse it.
+	collectionid, err := strconv.ParseUint(params.ByName("collectionid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing collectionid in deleting collection request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for collectionid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the collection exists and the user is its owner
+	check, err = rt.db.CheckCollectionOwner(collectionid, uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving collection information in deleting collection request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in deleting collection request! Collection doesn't exist")
+		http.Error(w, "Collection seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	err = rt.db.DeleteCollection(collectionid)
+	if err != nil {
+		context.Logger.Error("Error deleting collection.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong deleting your collection.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,13 @@
+package api
+
+import (
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// dismissReport allows a moderator to reject a report: the reported content is shown again to the users (if it was
+// hidden) and all its open reports are dismissed. See closeReport for details.
+func (rt *_router) dismissReport(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	rt.closeReport(w, r, params, context, ModerationDismiss)
+}
@@ -4,13 +4,16 @@ import (
 	"encoding/json"
 	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
 	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
 	"github.com/julienschmidt/httprouter"
 	"net/http"
+	"time"
 )
 
 // doLogin is the handler for the API endpoint POST /session.
 // It takes the username from the request body and returns the user object and the authorization token in a JSON object.
 // If the user does not exist, it creates a new user.
+// If the user has deactivated his account, the account is reactivated.
 // The request body must be a JSON object with the following fields:
 //   - username: string
 func (rt *_router) doLogin(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
@@ -25,6 +28,7 @@ func (rt *_router) doLogin(w http.ResponseWriter, r *http.Request, params httpro
 	}
 
 	// check if the username is valid
+	user.Username = normalizeText(user.Username)
 	if !user.IsValid() {
 		context.Logger.Error("Login username format is invalid")
 		http.Error(w, "Username format is not valid!", http.StatusBadRequest)
@@ -44,6 +48,34 @@ func (rt *_router) doLogin(w http.ResponseWriter, r *http.Request, params httpro
 	w.Header().Set("Content-Type", "application/json")
 
 	if !exists {
+		// a new username cannot be confusable with an existing one
+		confusable, err := rt.db.CheckExistsBySkeleton(database.UsernameSkeleton(userdb.Username), 0)
+		if err != nil {
+			context.Logger.Error(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if confusable {
+			context.Logger.Error("Login username is confusable with an existing one")
+			http.Error(w, "Username is too similar to an existing one!", http.StatusConflict)
+			return
+		}
+
+		// a username left by another user is reserved for a while
+		reserved, err := rt.usernameReserved(userdb.Username, database.UsernameSkeleton(userdb.Username), 0)
+		if err != nil {
+			context.Logger.Error(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if reserved {
+			context.Logger.Error("Login username has been recently released by another user")
+			http.Error(w, "Username has been recently released by another user!", http.StatusConflict)
+			return
+		}
+
 		// create the user
 		user, err = createUser(rt, user)
 		if err != nil {
@@ -63,6 +95,14 @@ func (rt *_router) doLogin(w http.ResponseWriter, r *http.Request, params httpro
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+
+		// logging in reactivates a deactivated account
+		err = reactivateUser(rt, user.Userid)
+		if err != nil {
+			context.Logger.Error(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 		context.Logger.Info("User correctly recovered!", user)
 		w.WriteHeader(http.StatusOK)
 
@@ -76,7 +116,7 @@ func createUser(rt *_router, user User) (User, error) {
 	var userdb database.User
 
 	userdb = user.ToDatabase()
-	userdb, err := rt.db.CreateUser(userdb.Username)
+	userdb, err := rt.db.CreateUser(userdb.Username, database.UsernameSkeleton(userdb.Username))
 
 	if err != nil {
 		return User{}, err
@@ -109,3 +149,16 @@ func recoverUserByUsername(rt *_router, user User) (User, error) {
 
 	return user, err
 }
+
+func reactivateUser(rt *_router, uid uint64) error {
+	status, err := rt.db.GetUserStatus(uid, globaltime.Now())
+	if err != nil {
+		return err
+	}
+
+	if status.Status != database.StatusDeactivated {
+		return nil
+	}
+
+	return rt.db.SetUserStatus(uid, database.StatusActive, "", time.Time{})
+}
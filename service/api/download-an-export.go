@@ -0,0 +1,51 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"os"
+)
+
+// downloadExport allows to download the archive of an export through its download link. The secret token in the link
+// authorizes the download, so that the link can be opened by a browser, until the expiration of the export.
+// If the token doesn't match a ready export, or the export is expired, the request will fail.
+func (rt *_router) downloadExport(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	export, err := rt.db.GetExportByToken(params.ByName("token"), globaltime.Now())
+	if errors.Is(err, sql.ErrNoRows) {
+		context.Logger.Error("Error in downloading export request! Export doesn't exist or it's expired")
+		http.Error(w, "Export seems not exist.", http.StatusNotFound)
+		return
+	} else if err != nil {
+		context.Logger.Error("Error retrieving export in downloading export request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving the export", http.StatusInternalServerError)
+		return
+	}
+
+	archive, err := os.Open(exportArchivePath(export.Exportid))
+	if err != nil {
+		context.Logger.Error("Error opening archive in downloading export request.\nDetail: ", err.Error())
+		http.Error(w, "Export seems not exist.", http.StatusNotFound)
+		return
+	}
+	defer func(archive *os.File) {
+		_ = archive.Close()
+	}(archive)
+
+	fileInfo, err := archive.Stat()
+	if err != nil {
+		context.Logger.Error("Something wrong retrieving archive Stat")
+		http.Error(w, "Something wrong retrieving the export", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"wasaphoto-%d.zip\"", export.Uid))
+
+	// NOTE: w.WriteHeader(http.StatusOK) is unnecessary because http.ServeContent already set it
+	http.ServeContent(w, r, fileInfo.Name(), fileInfo.ModTime(), archive)
+}
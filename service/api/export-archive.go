@@ -0,0 +1,191 @@
+package api
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	// ExportsDirectory is where the archives with the personal data of the users are stored
+	ExportsDirectory = "media/exports"
+	// ExportImagesDirectory is the directory of the images inside the archives
+	ExportImagesDirectory = "images"
+)
+
+// exportArchivePath returns the path of the archive file of an export
+func exportArchivePath(exportid uint64) string {
+	return filepath.Join(ExportsDirectory, strconv.FormatUint(exportid, 10)+".zip")
+}
+
+// writeExportArchive writes the ZIP archive with the personal data of a user to path. The archive contains:
+//   - profile.json: the profile of the user
+//   - posts.json: all his posts, with the paths of their images inside the archive
+//   - comments.json: his comments on any post
+//   - reactions.json: his reactions (likes included) to any post or comment
+//   - follows.json: the users that he follows and his followers
+//   - bans.json: the users that he has banned
+//   - images/: the original images of his posts
+//
+// The archive is written to a temporary file and then renamed, so that path is never a partial archive.
+func writeExportArchive(data database.ExportData, path string) error {
+	err := createDirs(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	archive := zip.NewWriter(file)
+	err = writeExportFiles(archive, data)
+	if err != nil {
+		return err
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// writeExportFiles writes the files of the archive described in writeExportArchive.
+func writeExportFiles(archive *zip.Writer, data database.ExportData) error {
+	var profile ProfileInfo
+	_ = profile.FromDatabase(data.Profile)
+	err := writeExportJSON(archive, "profile.json", map[string]ProfileInfo{"profile_info": profile})
+	if err != nil {
+		return err
+	}
+
+	posts := []ExportedPost{}
+	for _, post := range data.Posts {
+		exported := ExportedPost{
+			Postid:  post.Postid,
+			Caption: post.Caption,
+			Images:  []string{},
+		}
+		exported.Datetime, _ = formatDatetime(post.Datetime)
+		if post.Status != database.PostPublished {
+			exported.Status = post.Status
+			exported.PublishAt, _ = formatDatetime(post.PublishAt)
+		}
+
+		for _, media := range post.Media {
+			fileName, err := imageExists(strconv.FormatUint(media.Mediaid, 10), "media/img")
+			if err != nil {
+				return err
+			}
+			if fileName == "" {
+				// the image is missing, the post is exported without it
+				continue
+			}
+
+			name := ExportImagesDirectory + "/" + filepath.Base(fileName)
+			err = writeExportImage(archive, name, fileName)
+			if err != nil {
+				return err
+			}
+			exported.Images = append(exported.Images, name)
+		}
+		posts = append(posts, exported)
+	}
+	err = writeExportJSON(archive, "posts.json", map[string][]ExportedPost{"posts": posts})
+	if err != nil {
+		return err
+	}
+
+	comments := []Comment{}
+	for _, comment := range data.Comments {
+		var commentAPI Comment
+		_ = commentAPI.FromDatabase(comment)
+		commentAPI.Datetime, _ = formatDatetime(commentAPI.Datetime)
+		comments = append(comments, commentAPI)
+	}
+	err = writeExportJSON(archive, "comments.json", map[string][]Comment{"comments": comments})
+	if err != nil {
+		return err
+	}
+
+	reactions := []ExportedReaction{}
+	for _, reaction := range data.Reactions {
+		var reactionAPI ExportedReaction
+		_ = reactionAPI.FromDatabase(reaction)
+		reactions = append(reactions, reactionAPI)
+	}
+	err = writeExportJSON(archive, "reactions.json", map[string][]ExportedReaction{"reactions": reactions})
+	if err != nil {
+		return err
+	}
+
+	err = writeExportJSON(archive, "follows.json", map[string][]User{
+		"following": exportedUsers(data.Following),
+		"followers": exportedUsers(data.Followers),
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeExportJSON(archive, "bans.json", map[string][]User{"banned": exportedUsers(data.Banned)})
+}
+
+// exportedUsers converts a list of users of the database package to the API representation
+func exportedUsers(users []database.User) []User {
+	list := []User{}
+	for _, user := range users {
+		var userAPI User
+		_ = userAPI.FromDatabase(user)
+		list = append(list, userAPI)
+	}
+	return list
+}
+
+// writeExportJSON writes a value as an indented JSON file of the archive
+func writeExportJSON(archive *zip.Writer, name string, value interface{}) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeExportImage copies an image file in the archive
+func writeExportImage(archive *zip.Writer, name string, fileName string) error {
+	image, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer func(image *os.File) {
+		_ = image.Close()
+	}(image)
+
+	// images are already compressed
+	writer, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, image)
+	return err
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/gofrs/uuid"
+	"os"
+	"time"
+)
+
+const (
+	// ExportLinkDuration is how long the download link of an export is valid after the archive is built
+	ExportLinkDuration = 24 * time.Hour
+	// ExportWorkerInterval is how often the export worker looks for pending and expired exports
+	ExportWorkerInterval = time.Minute
+)
+
+// startExportWorker starts the background goroutine that builds the archives of the pending exports and removes the
+// expired ones, when an export is queued by queueExport and every ExportWorkerInterval. The exports requested before
+// a restart are built at start. The goroutine is stopped by Close.
+func (rt *_router) startExportWorker() {
+	rt.exportStop = make(chan struct{})
+	rt.exportDone = make(chan struct{})
+	rt.exportWake = make(chan struct{}, 1)
+
+	go func() {
+		defer close(rt.exportDone)
+
+		ticker := time.NewTicker(ExportWorkerInterval)
+		defer ticker.Stop()
+
+		for {
+			rt.buildPendingExports()
+			rt.removeExpiredExports()
+
+			select {
+			case <-rt.exportStop:
+				return
+			case <-ticker.C:
+			case <-rt.exportWake:
+			}
+		}
+	}()
+}
+
+// stopExportWorker stops the goroutine started by startExportWorker and waits for it to exit.
+func (rt *_router) stopExportWorker() {
+	close(rt.exportStop)
+	<-rt.exportDone
+}
+
+// queueExport requests a new export of the personal data of the user uid, and wakes up the export worker.
+func (rt *_router) queueExport(uid uint64) (database.Export, error) {
+	export, err := rt.db.AddExport(uid, globaltime.Now())
+	if err != nil {
+		return export, err
+	}
+
+	select {
+	case rt.exportWake <- struct{}{}:
+	default:
+		// the worker has already been woken up
+	}
+	return export, nil
+}
+
+// buildPendingExports builds the archives of the pending exports. An export whose archive cannot be built is marked
+// as failed. Errors are logged.
+func (rt *_router) buildPendingExports() {
+	exports, err := rt.db.GetPendingExports()
+	if err != nil {
+		rt.baseLogger.WithError(err).Error("error retrieving pending exports")
+		return
+	}
+
+	for _, export := range exports {
+		status := database.ExportReady
+		token, err := rt.buildExport(export)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error building archive of export %d", export.Exportid)
+			status = database.ExportFailed
+		}
+
+		err = rt.db.SetExportStatus(export.Exportid, status, token, globaltime.Now().Add(ExportLinkDuration))
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error updating export %d", export.Exportid)
+		}
+	}
+}
+
+// buildExport writes the archive of an export and returns the secret token of its download link.
+func (rt *_router) buildExport(export database.Export) (string, error) {
+	data, err := rt.db.GetExportData(export.Uid)
+	if err != nil {
+		return "", err
+	}
+
+	err = writeExportArchive(data, exportArchivePath(export.Exportid))
+	if err != nil {
+		return "", err
+	}
+
+	token, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return token.String(), nil
+}
+
+// removeExpiredExports removes the exports expired at globaltime.Now() and their archives. The archive is removed
+// before the export, so that an export whose archive cannot be removed is tried again at the next run. Errors are
+// logged.
+func (rt *_router) removeExpiredExports() {
+	exports, err := rt.db.GetExpiredExports(globaltime.Now())
+	if err != nil {
+		rt.baseLogger.WithError(err).Error("error retrieving expired exports")
+		return
+	}
+
+	for _, export := range exports {
+		err = os.Remove(exportArchivePath(export.Exportid))
+		if err != nil && !os.IsNotExist(err) {
+			rt.baseLogger.WithError(err).Errorf("error removing archive of export %d", export.Exportid)
+			continue
+		}
+
+		err = rt.db.RemoveExport(export.Exportid)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error removing export %d", export.Exportid)
+		}
+	}
+}
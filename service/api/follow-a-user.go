@@ -125,6 +125,7 @@ func (rt *_router) followUser(w http.ResponseWriter, r *http.Request, params htt
 			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
 			return
 		}
+		rt.suggestions.invalidate(uid)
 	}
 	w.WriteHeader(http.StatusCreated)
 	var user User
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// forceUsername allows an administrator to change the username of a user (e.g., an offensive one). The change is
+// written in the audit trail.
+// If the user is not authorized or he is not an administrator, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the username is not valid, the request will fail.
+// If the username is already taken (or confusable with an existing one), the request will fail.
+// If the request is OK, it will return the Account{} object of the user.
+func (rt *_router) forceUsername(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in forcing username request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in forcing username request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is an administrator
+	if context.Role != database.RoleAdmin {
+		context.Logger.Error("The user that makes forcing username request is not an administrator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for forcing username request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in forcing username request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	oldUsername, err := rt.db.GetUsername(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving username in forcing username request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	var user User
+	err = json.NewDecoder(r.Body).Decode(&user)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	user.Username = normalizeText(user.Username)
+	if !user.IsValid() {
+		context.Logger.Error("Error, User structure is not valid in forcing username request!")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// the new username cannot be confusable with the username of another user
+	skeleton := database.UsernameSkeleton(user.Username)
+	confusable, err := rt.db.CheckExistsBySkeleton(skeleton, uid)
+	if err != nil {
+		context.Logger.Error("Error checking confusable usernames in forcing username request\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if confusable {
+		context.Logger.Error("Error forcing the new username, confusable with an existing one")
+		http.Error(w, "Username is too similar to an existing one", http.StatusConflict)
+		return
+	}
+
+	err = rt.db.SetUsername(uid, user.Username, skeleton, globaltime.Now())
+	if err != nil {
+		context.Logger.Error("Error forcing the new username, already taken")
+		http.Error(w, "Username already taken. Username must be unique", http.StatusConflict)
+		return
+	}
+
+	err = rt.db.AddModerationAction(database.ModerationAction{
+		Actor:  context.Uid,
+		Action: ModerationRename,
+		Uid:    uid,
+		Detail: fmt.Sprintf("%s -> %s", oldUsername, user.Username),
+	})
+	if err != nil {
+		context.Logger.Error("Error writing audit trail in forcing username request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong changing the username.", http.StatusInternalServerError)
+		return
+	}
+
+	account, err := rt.getAccount(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving account in forcing username request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Account{"account": account})
+}
@@ -11,6 +11,9 @@ import (
 // getPost allows recovering a post passing its post id.
 // If the post id doesn't exist, the request will fail.
 // If the user is not authorized, the request will fail.
+// If the post is hidden by the moderation, the request will fail for the users that are not the owner or a moderator.
+// If the post owner has banned the user (or banned by him), the request will fail.
+// If the post is not published yet or it's archived, the request will fail for the users that are not the owner.
 func (rt *_router) getPost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
 	// The post ID in the path is a 64-bit unsigned integer. Let's parse it.
 	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
@@ -66,6 +69,22 @@ func (rt *_router) getPost(w http.ResponseWriter, r *http.Request, params httpro
 		http.Error(w, "Something wrong retrieving post", http.StatusInternalServerError)
 		return
 	}
+
+	// Drafts, scheduled posts, archived posts and posts hidden by the moderation are visible only to the owner (the
+	// hidden ones to the moderators too), and posts of users banned by (or that banned) the current user are not visible
+	visible, err := rt.canSeePost(postDB, context.Uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Something wrong checking post visibility\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving post", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Postid requested is not visible to the user")
+		http.Error(w, "Post seems not exist", http.StatusNotFound)
+		return
+	}
+
 	err = PostAPI.FromDatabase(postDB)
 	if err != nil {
 		context.Logger.Error("Something wrong casting post structure\nDetail: ", err.Error())
@@ -73,6 +92,13 @@ func (rt *_router) getPost(w http.ResponseWriter, r *http.Request, params httpro
 		return
 	}
 
+	err = rt.markLikedComments(&PostAPI, context.Uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving liked comments!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving post", http.StatusInternalServerError)
+		return
+	}
+
 	result := map[string]Post{
 		"post": PostAPI,
 	}
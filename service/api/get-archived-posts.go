@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getArchivedPosts allows a user to get the posts he has archived, in reverse chronological order of upload. Each post
+// has its likes and comments.
+// If the user in not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// Note: for getting a binary image it's necessary using the 'Get Image API'
+func (rt *_router) getArchivedPosts(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in get archive request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting archive request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting archive request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting archive request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting archive request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// Prepare return struct
+	posts := map[string][]Post{
+		"archive": {},
+	}
+
+	// Get the archived posts
+	listPost, err := rt.db.GetArchivedPosts(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post for user during getting archive request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your archive", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each post to the list
+	for i, post := range listPost {
+		var postAPI Post
+		err = postAPI.FromDatabase(post)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing postDB to postAPI for post number %d in getting archive request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your archive", http.StatusInternalServerError)
+			return
+		}
+		err = rt.markLikedComments(&postAPI, context.Uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving liked comments in getting archive request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong retrieving your archive", http.StatusInternalServerError)
+			return
+		}
+		// Change datetime format for each comment
+		for i := 0; i < len(postAPI.Comments); i++ {
+			postAPI.Comments[i].Datetime, _ = formatDatetime(postAPI.Comments[i].Datetime)
+		}
+		postAPI.Datetime, _ = formatDatetime(postAPI.Datetime)
+		posts["archive"] = append(posts["archive"], postAPI)
+	}
+
+	// Prepare the response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(posts)
+}
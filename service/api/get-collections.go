@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getCollections allows a user to get the collections of his saved posts, ordered by name.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// Each collection has the number of its saved posts that are still visible to the user.
+func (rt *_router) getCollections(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in getting collections request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting collections request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting collections request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting collections request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting collections request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// Prepare return struct
+	collections := map[string][]Collection{
+		"collections": {},
+	}
+
+	listCollection, err := rt.db.GetCollections(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving collections for user during getting collections request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your collections", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each collection to the list
+	for i, collection := range listCollection {
+		var collectionAPI Collection
+		err = collectionAPI.FromDatabase(collection)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing collectionDB to collectionAPI for collection number %d in getting collections request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your collections", http.StatusInternalServerError)
+			return
+		}
+		collectionAPI.Datetime, _ = formatDatetime(collectionAPI.Datetime)
+		collections["collections"] = append(collections["collections"], collectionAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(collections)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// ExploreWindowHours is the age (in hours) of the oldest posts shown in the explore feed
+	ExploreWindowHours uint64 = 24 * 7
+)
+
+// getExplore allows getting the explore feed of a user passing the uid: the popular recent posts of users he doesn't
+// follow, ranked by likes and comments velocity.
+// If the user in not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// Posts of users that have banned the user (or banned by him) are not returned.
+// The feed is paginated with the "offset" and "limit" query parameters.
+// Note: for getting a binary image it's necessary using the 'Get Image API'
+func (rt *_router) getExplore(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in get explore request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting explore request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting explore request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting explore request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting explore request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting explore request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Prepare return struct
+	posts := map[string][]Post{
+		"posts": {},
+	}
+
+	// Get the explore feed
+	listPost, err := rt.db.GetExplorePosts(uid, ExploreWindowHours, offset, limit)
+	if err != nil {
+		context.Logger.Error("Error retrieving post for user during getting explore request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your explore feed", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each post to the list
+	for i, post := range listPost {
+		var postAPI Post
+		err = postAPI.FromDatabase(post)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing postDB to postAPI for post number %d in getting explore request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your explore feed", http.StatusInternalServerError)
+			return
+		}
+		err = rt.markLikedComments(&postAPI, context.Uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving liked comments in getting explore request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong retrieving your explore feed", http.StatusInternalServerError)
+			return
+		}
+		// Change datetime format for each comment
+		for i := 0; i < len(postAPI.Comments); i++ {
+			postAPI.Comments[i].Datetime, _ = formatDatetime(postAPI.Comments[i].Datetime)
+		}
+		postAPI.Datetime, _ = formatDatetime(postAPI.Datetime)
+		posts["posts"] = append(posts["posts"], postAPI)
+	}
+
+	// Prepare the response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(posts)
+}
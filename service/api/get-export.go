@@ -0,0 +1,87 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getExport allows a user to get the status of his last export and, if its archive is ready, the download link, valid
+// until the expiration of the export.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the user has no export, the request will fail.
+func (rt *_router) getExport(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in getting export request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting export request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting export request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting export request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting export request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	exportDB, err := rt.db.GetLastExport(uid)
+	if errors.Is(err, sql.ErrNoRows) {
+		context.Logger.Error("Error in getting export request! The user has no export")
+		http.Error(w, "Export seems not exist.", http.StatusNotFound)
+		return
+	} else if err != nil {
+		context.Logger.Error("Error retrieving last export in getting export request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your export.", http.StatusInternalServerError)
+		return
+	}
+
+	var export Export
+	_ = export.FromDatabase(exportDB)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Export{"export": export})
+}
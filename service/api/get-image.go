@@ -13,7 +13,9 @@ import (
 // getImage allows recovering an image passing the image ID.
 // If the image id doesn't exist, the request will fail.
 // If the user is not authorized, the request will fail.
-// Note: image id is the same of post id.
+// If the user cannot see the post of the image (see canSeePost), the request will fail.
+// Note: image id is the media id of a photo in a post carousel (for posts uploaded before carousel support, it's the
+// same of post id).
 func (rt *_router) getImage(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
 	// The image ID in the path is a 64-bit unsigned integer. Let's parse it.
 	imageid, err := strconv.ParseUint(params.ByName("imageid"), 10, 64)
@@ -44,6 +46,48 @@ func (rt *_router) getImage(w http.ResponseWriter, r *http.Request, params httpr
 		return
 	}
 
+	// check if the image is a media of a post
+	check, err := rt.db.CheckMediaByMediaid(imageid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on media for getting an image!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Requested media doesn't exist")
+		http.Error(w, "Images seems not exist", http.StatusNotFound)
+		return
+	}
+
+	// check if the post of the image is visible to the user
+	postid, err := rt.db.GetMediaPostid(imageid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post of the media for getting an image!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information for getting an image!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	visible, err := rt.canSeePost(postDB, context.Uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility for getting an image!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Requested media is of a post not visible to the user")
+		http.Error(w, "Images seems not exist", http.StatusNotFound)
+		return
+	}
+
 	// check if the image exist
 	fileName := strconv.FormatUint(imageid, 10)
 	fileName, err = imageExists(fileName, "media/img")
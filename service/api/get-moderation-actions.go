@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// getModerationActions allows a moderator to get the moderation audit trail in reverse chronological order.
+// If the user is not authorized or he is not a moderator, the request will fail.
+// If the pagination is not valid, the request will fail.
+func (rt *_router) getModerationActions(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting audit trail request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is a moderator
+	if !canModerate(context.Role) {
+		context.Logger.Error("The user that makes getting audit trail request is not a moderator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting audit trail request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Prepare return struct
+	actions := map[string][]ModerationAction{
+		"actions": {},
+	}
+
+	listAction, err := rt.db.GetModerationActions(offset, limit)
+	if err != nil {
+		context.Logger.Error("Error retrieving audit trail during getting audit trail request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving the audit trail", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each action to the list
+	for i, action := range listAction {
+		var actionAPI ModerationAction
+		err = actionAPI.FromDatabase(action)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing actionDB to actionAPI for action number %d in getting audit trail request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving the audit trail", http.StatusInternalServerError)
+			return
+		}
+		actionAPI.Datetime, _ = formatDatetime(actionAPI.Datetime)
+		actions["actions"] = append(actions["actions"], actionAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(actions)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getNotifications allows getting the notifications of a user (e.g., mentions in comments) passing the uid.
+// If the user in not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// The list is paginated with the "offset" and "limit" query parameters, in reverse chronological order.
+func (rt *_router) getNotifications(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in getting notifications request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting notifications request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting notifications request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting notifications request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting notifications request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting notifications request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Prepare return struct
+	notifications := map[string][]Notification{
+		"notifications": {},
+	}
+
+	notificationsDb, err := rt.db.GetNotifications(uid, offset, limit)
+	if err != nil {
+		context.Logger.Error("Error retrieving notifications for user\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your notifications", http.StatusInternalServerError)
+		return
+	}
+
+	for i, notification := range notificationsDb {
+		var notificationAPI Notification
+		err = notificationAPI.FromDatabase(notification)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing notificationDB to notificationAPI for notification number %d\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your notifications", http.StatusInternalServerError)
+			return
+		}
+		notificationAPI.Datetime, _ = formatDatetime(notificationAPI.Datetime)
+		notifications["notifications"] = append(notifications["notifications"], notificationAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(notifications)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// getReports allows a moderator to get the moderation queue, the oldest reports first. The "status" query parameter
+// selects the reports to return: "open" (default), "resolved", "dismissed" or "all".
+// If the user is not authorized or he is not a moderator, the request will fail.
+// If the status or the pagination are not valid, the request will fail.
+func (rt *_router) getReports(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting reports request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is a moderator
+	if !canModerate(context.Role) {
+		context.Logger.Error("The user that makes getting reports request is not a moderator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = database.ReportOpen
+	case "all":
+		status = ""
+	case database.ReportOpen, database.ReportResolved, database.ReportDismissed:
+	default:
+		context.Logger.Error("Error parsing status in getting reports request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct value for status",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting reports request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Prepare return struct
+	reports := map[string][]Report{
+		"reports": {},
+	}
+
+	listReport, err := rt.db.GetReports(status, offset, limit)
+	if err != nil {
+		context.Logger.Error("Error retrieving reports during getting reports request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving the reports", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each report to the list
+	for i, report := range listReport {
+		var reportAPI Report
+		err = reportAPI.FromDatabase(report)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing reportDB to reportAPI for report number %d in getting reports request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving the reports", http.StatusInternalServerError)
+			return
+		}
+		reportAPI.Datetime, _ = formatDatetime(reportAPI.Datetime)
+		reports["reports"] = append(reports["reports"], reportAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(reports)
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getSavedPosts allows getting the posts saved by a user passing the uid, in reverse chronological order of saving.
+// The "collection" query parameter selects the posts of one of his collections.
+// If the user in not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the collection is not one of the user's collections, the request will fail.
+// Posts that have been deleted, or whose owner has banned the user (or banned by him), are not returned.
+// The list is paginated with the "offset" and "limit" query parameters.
+// Note: for getting a binary image it's necessary using the 'Get Image API'
+func (rt *_router) getSavedPosts(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in get saved posts request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting saved posts request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting saved posts request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting saved posts request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting saved posts request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting saved posts request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// The collection in the query is optional and it's a 64-bit unsigned integer. Let's parse it.
+	collectionid := uint64(0)
+	if value := r.URL.Query().Get("collection"); value != "" {
+		collectionid, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			context.Logger.Error("Error parsing collection in getting saved posts request")
+			w.WriteHeader(http.StatusBadRequest)
+
+			response := map[string]string{
+				"error": "not correct format for collection",
+			}
+
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		check, err = rt.db.CheckCollectionOwner(collectionid, uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving collection information in getting saved posts request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+			return
+		}
+
+		if !check {
+			context.Logger.Error("Error in getting saved posts request! Collection doesn't exist")
+			http.Error(w, "Collection seems not exist.", http.StatusNotFound)
+			return
+		}
+	}
+
+	// Prepare return struct
+	posts := map[string][]Post{
+		"posts": {},
+	}
+
+	// Get the saved posts
+	listPost, err := rt.db.GetSavedPosts(uid, collectionid, offset, limit)
+	if err != nil {
+		context.Logger.Error("Error retrieving post for user during getting saved posts request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your saved posts", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each post to the list
+	for i, post := range listPost {
+		var postAPI Post
+		err = postAPI.FromDatabase(post)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing postDB to postAPI for post number %d in getting saved posts request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your saved posts", http.StatusInternalServerError)
+			return
+		}
+		err = rt.markLikedComments(&postAPI, context.Uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving liked comments in getting saved posts request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong retrieving your saved posts", http.StatusInternalServerError)
+			return
+		}
+		// Change datetime format for each comment
+		for i := 0; i < len(postAPI.Comments); i++ {
+			postAPI.Comments[i].Datetime, _ = formatDatetime(postAPI.Comments[i].Datetime)
+		}
+		postAPI.Datetime, _ = formatDatetime(postAPI.Datetime)
+		posts["posts"] = append(posts["posts"], postAPI)
+	}
+
+	// Prepare the response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(posts)
+}
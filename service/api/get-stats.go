@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// getStats allows an administrator to get the system statistics.
+// If the user is not authorized or he is not an administrator, the request will fail.
+func (rt *_router) getStats(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting stats request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is an administrator
+	if context.Role != database.RoleAdmin {
+		context.Logger.Error("The user that makes getting stats request is not an administrator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	statsDB, err := rt.db.GetStats(globaltime.Now())
+	if err != nil {
+		context.Logger.Error("Error retrieving stats.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving the stats.", http.StatusInternalServerError)
+		return
+	}
+
+	var stats Stats
+	_ = stats.FromDatabase(statsDB)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Stats{"stats": stats})
+}
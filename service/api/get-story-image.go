@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// getStoryImage allows recovering the image of a story passing the story ID.
+// If the user is not authorized, the request will fail.
+// If the story doesn't exist or it's expired, the request will fail.
+// If the user is not the owner and he doesn't follow the owner (or there is a ban between them), the request will fail.
+func (rt *_router) getStoryImage(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The Story ID in the path is a 64-bit unsigned integer. Let's parse it.
+	storyid, err := strconv.ParseUint(params.ByName("storyid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing storyid in getting story image request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for storyid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid for getting a story image!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the story exists and it's not expired
+	check, err := rt.db.CheckStoryByStoryid(storyid, globaltime.Now())
+	if err != nil {
+		context.Logger.Error("Error retrieving information on story for getting a story image!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Requested story doesn't exist")
+		http.Error(w, "Story seems not exist", http.StatusNotFound)
+		return
+	}
+
+	storyDB, err := rt.db.GetStory(storyid)
+	if err != nil {
+		context.Logger.Error("Error retrieving story for getting a story image!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	// check if the story is visible to the user
+	check, err = rt.canSeeStory(storyDB, context.Uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving follow and ban information for getting a story image!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Requested story is not visible to the user")
+		http.Error(w, "Story seems not exist", http.StatusNotFound)
+		return
+	}
+
+	// Read the image content and prepare it for sending
+	fileName := storyImagePath(storyDB)
+	imageFile, err := os.Open(fileName)
+	if err != nil {
+		context.Logger.Error("Error during story image opening\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+	defer func(imageFile *os.File) {
+		_ = imageFile.Close()
+	}(imageFile)
+
+	fileInfo, err := imageFile.Stat()
+	if err != nil {
+		context.Logger.Error("Something wrong retrieving story image Stat")
+		http.Error(w, "Something wrong retrieving image", http.StatusInternalServerError)
+		return
+	}
+
+	// Set Content-Type Header to image/png or image/jpeg
+	w.Header().Set("Content-Type", "image/"+storyDB.Format)
+
+	// Now return the binary image
+	// NOTE: w.WriteHeader(http.StatusOK) is unnecessary because http.ServeContent already set it
+	http.ServeContent(w, r, fileName, fileInfo.ModTime(), imageFile)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getStoryViewers allows the owner of a story to get the users that have seen it, from the most recent view.
+// If the user is not authorized, the request will fail.
+// If the story doesn't exist or it's expired, the request will fail.
+// If the user is not the story owner, the request will fail.
+func (rt *_router) getStoryViewers(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The Story ID in the path is a 64-bit unsigned integer. Let's parse it.
+	storyid, err := strconv.ParseUint(params.ByName("storyid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing storyid in getting story viewers request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for storyid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting story viewers request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the story exists and it's not expired
+	check, err := rt.db.CheckStoryByStoryid(storyid, globaltime.Now())
+	if err != nil {
+		context.Logger.Error("Error retrieving information on storyid for getting story viewers!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting story viewers request! Story doesn't exist")
+		http.Error(w, "Story seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	storyDB, err := rt.db.GetStory(storyid)
+	if err != nil {
+		context.Logger.Error("Error retrieving story information in getting story viewers request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	// only the owner can see the viewers
+	if storyDB.Uid != context.Uid {
+		context.Logger.Error("Error in getting story viewers request! User is not the story owner")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// Prepare return struct
+	viewers := map[string][]StoryView{
+		"viewers": {},
+	}
+
+	listViewer, err := rt.db.GetStoryViewers(storyid)
+	if err != nil {
+		context.Logger.Error("Error retrieving viewers during getting story viewers request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving the viewers", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each viewer to the list
+	for i, viewer := range listViewer {
+		var viewerAPI StoryView
+		err = viewerAPI.FromDatabase(viewer)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing viewerDB to viewerAPI for viewer number %d in getting story viewers request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving the viewers", http.StatusInternalServerError)
+			return
+		}
+		viewerAPI.Datetime, _ = formatDatetime(viewerAPI.Datetime)
+		viewers["viewers"] = append(viewers["viewers"], viewerAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(viewers)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// getStreamStories allows a user to get the stories of the users he follows, grouped by user.
+// Groups with stories not seen yet come first; then groups are ordered from the most recent story.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+func (rt *_router) getStreamStories(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in getting stream stories request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting stream stories request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting stream stories request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting stream stories request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting stream stories request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	listStory, err := rt.db.GetStreamStories(uid, globaltime.Now())
+	if err != nil {
+		context.Logger.Error("Error retrieving stories for user during getting stream stories request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your stories", http.StatusInternalServerError)
+		return
+	}
+
+	// Stories are ordered by owner, so a new group starts when the owner changes
+	groups := []StoryGroup{}
+	for i, story := range listStory {
+		var storyAPI Story
+		err = storyAPI.FromDatabase(story)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing storyDB to storyAPI for story number %d in getting stream stories request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your stories", http.StatusInternalServerError)
+			return
+		}
+		storyAPI.Datetime, _ = formatDatetime(storyAPI.Datetime)
+		storyAPI.Expiration, _ = formatDatetime(storyAPI.Expiration)
+
+		if len(groups) == 0 || groups[len(groups)-1].User.Userid != storyAPI.Uid {
+			username, err := rt.db.GetUsername(storyAPI.Uid)
+			if err != nil {
+				context.Logger.Error("Error retrieving username in getting stream stories request!\nDetail: ", err.Error())
+				http.Error(w, "Something wrong retrieving your stories", http.StatusInternalServerError)
+				return
+			}
+			groups = append(groups, StoryGroup{User: User{Userid: storyAPI.Uid, Username: username}, Seen: true})
+		}
+
+		group := &groups[len(groups)-1]
+		group.Stories = append(group.Stories, storyAPI)
+		group.Seen = group.Seen && storyAPI.Seen
+	}
+
+	// Groups with stories to see first, then from the most recent story (datetimes have the same format)
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Seen != groups[j].Seen {
+			return !groups[i].Seen
+		}
+		latestI := groups[i].Stories[len(groups[i].Stories)-1].Datetime
+		latestJ := groups[j].Stories[len(groups[j].Stories)-1].Datetime
+		return latestI > latestJ
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string][]StoryGroup{"stories": groups})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getSuggestions allows getting the "people you may know" suggestions of a user passing the uid: the users followed
+// by the people he follows, ranked by the number of mutual connections, each with the reason of the suggestion.
+// If the user in not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// Users already followed, users that have banned the user and users banned by him are not returned.
+// Suggestions are cached for SuggestionsCacheTTL and paginated with the "offset" and "limit" query parameters.
+func (rt *_router) getSuggestions(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in get suggestions request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting suggestions request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting suggestions request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting suggestions request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting suggestions request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting suggestions request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Get the suggestions from the cache, computing them if they are missing or expired
+	listSuggestion, ok := rt.suggestions.get(uid)
+	if !ok {
+		listSuggestion, err = rt.db.GetFollowSuggestions(uid, SuggestionsMaxCount)
+		if err != nil {
+			context.Logger.Error("Error retrieving suggestions for user during getting suggestions request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong retrieving your suggestions", http.StatusInternalServerError)
+			return
+		}
+		rt.suggestions.set(uid, listSuggestion)
+	}
+
+	// Prepare return struct
+	suggestions := map[string][]Suggestion{
+		"suggestions": {},
+	}
+
+	// Select the requested page
+	if offset > uint64(len(listSuggestion)) {
+		offset = uint64(len(listSuggestion))
+	}
+	end := offset + limit
+	if end > uint64(len(listSuggestion)) {
+		end = uint64(len(listSuggestion))
+	}
+
+	// Append each suggestion to the list
+	for i, suggestion := range listSuggestion[offset:end] {
+		var suggestionAPI Suggestion
+		err = suggestionAPI.FromDatabase(suggestion)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing suggestionDB to suggestionAPI for suggestion number %d in getting suggestions request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your suggestions", http.StatusInternalServerError)
+			return
+		}
+		suggestions["suggestions"] = append(suggestions["suggestions"], suggestionAPI)
+	}
+
+	// Prepare the response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(suggestions)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// getTagPosts allows getting the posts that use a hashtag, in their caption or in a comment.
+// If the user is not authorized, the request will fail.
+// If the tag is not well formatted, the request will fail.
+// Tags are case-insensitive. Posts of users that have banned the current user (or banned by him) are not returned.
+// The list is paginated with the "offset" and "limit" query parameters, in reverse chronological order.
+func (rt *_router) getTagPosts(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting tag posts request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	check, err := rt.db.CheckExistsByUID(context.Uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID that makes getting tag posts request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting tag posts request! User that makes request doesn't exist!")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The tag in the path is a string, with or without the leading '#'. Let's normalize it.
+	tag := normalizeTag(params.ByName("tag"))
+	if tag == "" {
+		context.Logger.Error("Error parsing tag in getting tag posts request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for tag",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting tag posts request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Prepare return struct
+	posts := map[string][]Post{
+		"posts": {},
+	}
+
+	listPost, err := rt.db.GetTagPosts(tag, context.Uid, offset, limit)
+	if err != nil {
+		context.Logger.Error("Error retrieving posts for tag during getting tag posts request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving tag posts", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each post to the list
+	for i, post := range listPost {
+		var postAPI Post
+		err = postAPI.FromDatabase(post)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing postDB to postAPI for post number %d in getting tag posts request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving tag posts", http.StatusInternalServerError)
+			return
+		}
+		err = rt.markLikedComments(&postAPI, context.Uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving liked comments in getting tag posts request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong retrieving tag posts", http.StatusInternalServerError)
+			return
+		}
+		// Change datetime format for each comment
+		for i := 0; i < len(postAPI.Comments); i++ {
+			postAPI.Comments[i].Datetime, _ = formatDatetime(postAPI.Comments[i].Datetime)
+		}
+		postAPI.Datetime, _ = formatDatetime(postAPI.Datetime)
+		posts["posts"] = append(posts["posts"], postAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(posts)
+}
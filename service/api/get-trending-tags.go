@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// DefaultTrendingHours is the default time window (in hours) used to compute trending tags
+	DefaultTrendingHours uint64 = 24
+	// MaxTrendingHours is the maximum time window (in hours) used to compute trending tags
+	MaxTrendingHours uint64 = 24 * 7
+)
+
+// getTrendingTags allows getting the most used hashtags in a sliding time window.
+// If the user is not authorized, the request will fail.
+// The "hours" query parameter sets the time window (default 24 hours) and the "limit" one the number of tags returned.
+// Tags are ordered by the number of posts that used them in the time window.
+func (rt *_router) getTrendingTags(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting trending tags request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	hours := DefaultTrendingHours
+	if value := r.URL.Query().Get("hours"); value != "" {
+		var err error
+		hours, err = strconv.ParseUint(value, 10, 64)
+		if err != nil || hours == 0 || hours > MaxTrendingHours {
+			context.Logger.Error("Error parsing hours in getting trending tags request")
+			w.WriteHeader(http.StatusBadRequest)
+
+			response := map[string]string{
+				"error": "not correct format for hours",
+			}
+
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+	}
+
+	_, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing limit in getting trending tags request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Prepare return struct
+	tags := map[string][]Tag{
+		"tags": {},
+	}
+
+	tagsDb, err := rt.db.GetTrendingTags(hours, limit)
+	if err != nil {
+		context.Logger.Error("Error retrieving trending tags\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving trending tags", http.StatusInternalServerError)
+		return
+	}
+
+	for i, tag := range tagsDb {
+		var tagAPI Tag
+		err = tagAPI.FromDatabase(tag)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing tagDB to tagAPI for tag number %d\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving trending tags", http.StatusInternalServerError)
+			return
+		}
+		tags["tags"] = append(tags["tags"], tagAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(tags)
+}
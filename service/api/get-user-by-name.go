@@ -0,0 +1,119 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// getUserByName allows getting a user and his profile information from his username, so that profiles can be shared
+// with their username. Usernames are matched case-insensitively (an exact match has precedence). A username left by
+// a user (and not taken by anyone else) is resolved to his account, so that links and mentions with the old username
+// keep working: the returned user has the current username.
+// If the user is not authorized, the request will fail.
+// If the current user doesn't exist, the request will fail.
+// If no user has (or had) the username, the user is deleted (or being deleted) or he has banned the current user, the
+// request will fail.
+func (rt *_router) getUserByName(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting user by name request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	check, err := rt.db.CheckExistsByUID(context.Uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID that makes getting user by name request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting user by name request! User that makes request doesn't exist!")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	username := normalizeText(params.ByName("username"))
+
+	// the current usernames have precedence over the old ones
+	userdb, err := rt.db.GetUserByUsername(username)
+	if errors.Is(err, sql.ErrNoRows) {
+		userdb, err = rt.db.GetUserByOldUsername(username)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		context.Logger.Error("Error in getting user by name request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	} else if err != nil {
+		context.Logger.Error("Error retrieving user in getting user by name request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	// deleted users keep a tombstone, and the users being deleted keep their username until the deletion ends
+	check, err = rt.db.CheckExistsByUID(userdb.Userid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting user by name request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting user by name request! User is deleted!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// Check ban information
+	banned, err := rt.db.HasBanned(userdb.Userid, context.Uid)
+	if err != nil {
+		context.Logger.Error("Error getting ban information in getting user by name request\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if banned {
+		context.Logger.Error("Error in getting user by name request! User is banned!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	var user User
+	err = user.FromDatabase(userdb)
+	if err != nil {
+		context.Logger.Error("Error parsing User Database to User API")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Get Profile information
+	profileDB, err := rt.db.GetProfileInfo(userdb.Userid)
+	if err != nil {
+		context.Logger.Error("Error getting ProfileDB in getting user by name request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving user profile", http.StatusInternalServerError)
+		return
+	}
+
+	var profileInfo ProfileInfo
+	_ = profileInfo.FromDatabase(profileDB)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"user":         user,
+		"profile_info": profileInfo,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
 	"github.com/julienschmidt/httprouter"
 	"net/http"
 	"strconv"
@@ -12,7 +13,8 @@ import (
 // getUserProfile allows getting user's profile information passing the uid.
 // If the user is not authorized, the request will fail.
 // If the user id doesn't exist, the request will fail.
-// The return values will be all the user information and his upload post stream in reverse chronological order
+// The return values will be all the user information, the posts pinned by the user (the last pinned first) and the rest
+// of his upload post stream in reverse chronological order
 // The stream consists in an array of post. (Check API documentation for detail)
 // Note: for getting a binary image it's necessary using the 'Get Image API'
 func (rt *_router) getUserProfile(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
@@ -89,7 +91,6 @@ func (rt *_router) getUserProfile(w http.ResponseWriter, r *http.Request, params
 
 	// Prepare return statement
 	var profileInfo ProfileInfo
-	var uploadedPost []Post
 
 	profileInfo.User.Userid = uid
 
@@ -111,6 +112,20 @@ func (rt *_router) getUserProfile(w http.ResponseWriter, r *http.Request, params
 
 	_ = profileInfo.FromDatabase(profileDB)
 
+	// Get pinned posts, the last pinned first
+	listPinned, err := rt.db.GetPinnedPosts(userDb.Userid)
+	if err != nil {
+		context.Logger.Error("Error retrieving user pinned posts during getting profile request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving profile", http.StatusInternalServerError)
+		return
+	}
+
+	pinnedPost, err := rt.profilePosts(listPinned, &context)
+	if err != nil {
+		http.Error(w, "Something wrong retrieving your profile", http.StatusInternalServerError)
+		return
+	}
+
 	// Get profile posts in reverse chronological order
 	listPost, err := rt.db.GetProfilePosts(userDb.Userid)
 	if err != nil {
@@ -119,28 +134,47 @@ func (rt *_router) getUserProfile(w http.ResponseWriter, r *http.Request, params
 		return
 	}
 
+	uploadedPost, err := rt.profilePosts(listPost, &context)
+	if err != nil {
+		http.Error(w, "Something wrong retrieving your profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(&map[string]interface {
+	}{"profile_info": profileInfo,
+		"pinned_posts":  pinnedPost,
+		"uploaded_post": uploadedPost})
+
+}
+
+// profilePosts converts the posts of a profile for the response of getUserProfile, marking the comments liked by the
+// current user.
+func (rt *_router) profilePosts(listPost []database.Post, context *reqcontext.RequestContext) ([]Post, error) {
+	var posts []Post
+
 	// Append each post to the list
 	for i, post := range listPost {
 		var postAPI Post
-		err = postAPI.FromDatabase(post)
+		err := postAPI.FromDatabase(post)
 		if err != nil {
 			mess := fmt.Sprintf("Error parsing postDB to postAPI for post number %d in getting profile request\nDetail: ", i)
 			context.Logger.Error(mess, err.Error())
-			http.Error(w, "Something wrong retrieving your profile", http.StatusInternalServerError)
-			return
+			return nil, err
+		}
+		err = rt.markLikedComments(&postAPI, context.Uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving liked comments in getting profile request!\nDetail: ", err.Error())
+			return nil, err
 		}
 		// Change datetime format for each comment
 		for i := 0; i < len(postAPI.Comments); i++ {
 			postAPI.Comments[i].Datetime, _ = formatDatetime(postAPI.Comments[i].Datetime)
 		}
 		postAPI.Datetime, _ = formatDatetime(postAPI.Datetime)
-		uploadedPost = append(uploadedPost, postAPI)
+		posts = append(posts, postAPI)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(&map[string]interface {
-	}{"profile_info": profileInfo,
-		"uploaded_post": uploadedPost})
-
+	return posts, nil
 }
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// getUserStories allows a user to get his stories that are not expired yet, in upload order, with the number of
+// users that have seen each of them.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+func (rt *_router) getUserStories(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in getting stories request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in getting stories request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes getting stories request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for getting stories request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in getting stories request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// Prepare return struct
+	stories := map[string][]Story{
+		"stories": {},
+	}
+
+	listStory, err := rt.db.GetUserStories(uid, globaltime.Now())
+	if err != nil {
+		context.Logger.Error("Error retrieving stories for user during getting stories request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong retrieving your stories", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each story to the list
+	for i, story := range listStory {
+		var storyAPI Story
+		err = storyAPI.FromDatabase(story)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing storyDB to storyAPI for story number %d in getting stories request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong retrieving your stories", http.StatusInternalServerError)
+			return
+		}
+		storyAPI.Datetime, _ = formatDatetime(storyAPI.Datetime)
+		storyAPI.Expiration, _ = formatDatetime(storyAPI.Expiration)
+		stories["stories"] = append(stories["stories"], storyAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(stories)
+}
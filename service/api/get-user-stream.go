@@ -14,6 +14,7 @@ import (
 // If the user id doesn't exist, the request will fail.
 // If the post id doesn't exist, the request will fail.
 // The stream consists in an array of post. (Check API documentation for detail)
+// Posts reposted by the followed users have the repost field, with the user that reposted them and the quote.
 // Note: for getting a binary image it's necessary using the 'Get Image API'
 func (rt *_router) getMyStream(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
 	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
@@ -90,11 +91,20 @@ func (rt *_router) getMyStream(w http.ResponseWriter, r *http.Request, params ht
 			http.Error(w, "Something wrong retrieving your stream", http.StatusInternalServerError)
 			return
 		}
+		err = rt.markLikedComments(&postAPI, context.Uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving liked comments in getting stream request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong retrieving your stream", http.StatusInternalServerError)
+			return
+		}
 		// Change datetime format for each comment
 		for i := 0; i < len(postAPI.Comments); i++ {
 			postAPI.Comments[i].Datetime, _ = formatDatetime(postAPI.Comments[i].Datetime)
 		}
 		postAPI.Datetime, _ = formatDatetime(postAPI.Datetime)
+		if postAPI.Repost != nil {
+			postAPI.Repost.Datetime, _ = formatDatetime(postAPI.Repost.Datetime)
+		}
 		posts["posts"] = append(posts["posts"], postAPI)
 	}
 
@@ -0,0 +1,53 @@
+// Package api
+/* This file consists in all the function used to extract and normalize hashtags */
+package api
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// HashtagRegex matches a '#' (not preceded by a letter, a digit, an underscore or a '&') followed by the tag
+	HashtagRegex string = "(?:^|[^\\p{L}\\p{M}\\p{N}_&])#([\\p{L}\\p{M}\\p{N}_]+)"
+	// TagRegex is the format of a normalized tag, without the leading '#'
+	TagRegex string = "^[\\p{L}\\p{M}\\p{N}_]+$"
+
+	// TagMaxLength is the maximum length of a tag in user-perceived characters
+	TagMaxLength int = 64
+)
+
+// normalizeTag returns the tag in the form used to index it: NFC normalized, lowercase and without the leading '#'.
+// If the tag is not valid, it will return "".
+func normalizeTag(tag string) string {
+	tag = strings.ToLower(normalizeText(strings.TrimPrefix(tag, "#")))
+
+	regexPattern := regexp.MustCompile(TagRegex)
+	if !regexPattern.MatchString(tag) || graphemeCount(tag) > TagMaxLength {
+		return ""
+	}
+
+	// A tag needs at least a letter: "#1" is not a tag
+	if strings.IndexFunc(tag, unicode.IsLetter) < 0 {
+		return ""
+	}
+	return tag
+}
+
+// extractHashtags returns the normalized hashtags contained in a text (caption or comment message), without
+// duplicates and in order of appearance.
+func extractHashtags(text string) []string {
+	regexPattern := regexp.MustCompile(HashtagRegex)
+
+	var tags []string
+	found := map[string]bool{}
+	for _, match := range regexPattern.FindAllStringSubmatch(normalizeText(text), -1) {
+		tag := normalizeTag(match[1])
+		if tag != "" && !found[tag] {
+			found[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{"plain", "roma", "roma"},
+		{"leading hash", "#roma", "roma"},
+		{"uppercase", "#Roma", "roma"},
+		{"underscore", "#sunset_2023", "sunset_2023"},
+		{"digits only", "#2023", ""},
+		{"combining accent", "#café", "café"},
+		{"non latin", "#東京", "東京"},
+		{"punctuation", "#roma!", ""},
+		{"space", "#new york", ""},
+		{"empty", "#", ""},
+		{"max length", "#" + strings.Repeat("a", TagMaxLength), strings.Repeat("a", TagMaxLength)},
+		{"too long", "#" + strings.Repeat("a", TagMaxLength+1), ""},
+		{"max length with accents", "#" + strings.Repeat("é", TagMaxLength), strings.Repeat("é", TagMaxLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeTag(tt.tag); got != tt.want {
+				t.Errorf("normalizeTag(%+q) = %+q, want %+q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"no tags", "a sunny day", nil},
+		{"single", "#roma", []string{"roma"}},
+		{"in order", "sunset in #Roma with #friends", []string{"roma", "friends"}},
+		{"duplicates", "#Roma and #roma and #ROMA", []string{"roma"}},
+		{"after punctuation", "(#roma), #summer.", []string{"roma", "summer"}},
+		{"inside a word", "a#b and mail#roma", nil},
+		{"after underscore", "_#roma", nil},
+		{"html entity", "it&#39;s", nil},
+		{"digits only", "#1 #2023", nil},
+		{"double hash", "##roma", []string{"roma"}},
+		{"combining accent", "#café #café", []string{"café"}},
+		{"non latin", "東京 #東京", []string{"東京"}},
+		{"too long", "#" + strings.Repeat("a", TagMaxLength+1) + " #roma", []string{"roma"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractHashtags(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractHashtags(%+q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
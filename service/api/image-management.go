@@ -4,12 +4,16 @@ package api
 
 import (
 	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"image"
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 // detectImageType allows to determine if the MIME type is a PNG or a JPEG.
@@ -27,6 +31,32 @@ func detectImageType(data []byte, context *reqcontext.RequestContext) string {
 	}
 }
 
+// checkImageType allows to determine the type of an image ("png" or "jpeg") checking that the declared Content-Type
+// matches the binary content.
+// If the type is neither PNG nor JPEG, or it doesn't match the content, it will return ""
+func checkImageType(data []byte, contentType string, context *reqcontext.RequestContext) string {
+	imageType := detectImageType(data, context)
+	switch {
+	case strings.HasPrefix(contentType, "image/png") && imageType == "png":
+		context.Logger.Info("Image is correct PNG")
+	case strings.HasPrefix(contentType, "image/jpeg") && imageType == "jpeg":
+		context.Logger.Info("Image is a correct JPEG")
+	default:
+		context.Logger.Error("Content-Type is not PNG or JPEG, or the body doesn't match it")
+		return ""
+	}
+	return imageType
+}
+
+// imageSize allows to read the width and the height (in pixels) of a PNG or JPEG image without decoding it entirely.
+func imageSize(data io.Reader) (uint64, uint64, error) {
+	config, _, err := image.DecodeConfig(data)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint64(config.Width), uint64(config.Height), nil
+}
+
 // createDir allows to create a complete path
 // If the folders in the path are already created, nothing happens
 func createDirs(path string) error {
@@ -106,10 +136,58 @@ func imageExists(fileName string, filePath string) (string, error) {
 	return "", nil
 }
 
-// deleteImage allows to remove a file owned by webserver.
-// Function will return nil if file is correctly removed, an error otherwise
-func deleteImage(pathFile string, nameFile string) error {
-	fullPath := pathFile + nameFile
-	err := os.Remove(fullPath)
-	return err
+// deleteMediaImages allows to remove the image files of the media of a post.
+// Function will return nil if all the files are correctly removed (or they are already missing), an error otherwise
+func deleteMediaImages(media []database.Media) error {
+	for _, item := range media {
+		fileName, err := imageExists(strconv.FormatUint(item.Mediaid, 10), "media/img")
+		if err != nil {
+			return err
+		}
+		if fileName == "" {
+			continue
+		}
+		err = os.Remove(fileName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completeMediaInfo reads the format and the size of the images uploaded before carousel support, and saves them in
+// the database. Errors are logged, as they only affect the size returned for these images.
+func (rt *_router) completeMediaInfo() {
+	media, err := rt.db.GetMediaWithoutInfo()
+	if err != nil {
+		rt.baseLogger.WithError(err).Error("error retrieving media without format and size")
+		return
+	}
+
+	for _, item := range media {
+		fileName, err := imageExists(strconv.FormatUint(item.Mediaid, 10), "media/img")
+		if err != nil || fileName == "" {
+			rt.baseLogger.WithError(err).Warnf("image of media %d not found", item.Mediaid)
+			continue
+		}
+
+		file, err := os.Open(fileName)
+		if err != nil {
+			rt.baseLogger.WithError(err).Warnf("error reading image of media %d", item.Mediaid)
+			continue
+		}
+
+		item.Format = strings.TrimPrefix(filepath.Ext(fileName), ".")
+		item.Width, item.Height, err = imageSize(file)
+		_ = file.Close()
+		if err != nil {
+			rt.baseLogger.WithError(err).Warnf("error reading size of media %d", item.Mediaid)
+			continue
+		}
+
+		err = rt.db.SetMediaInfo(item)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error saving format and size of media %d", item.Mediaid)
+		}
+	}
 }
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	// ImportMaxSize is the maximum size of an archive to import
+	ImportMaxSize int64 = 512 << 20
+	// ImportMaxImageSize is the maximum size of an image inside an archive to import
+	ImportMaxImageSize int64 = 32 << 20
+)
+
+// errImportRejected is wrapped by the errors of importPost caused by the content of the archive
+var errImportRejected = errors.New("post rejected")
+
+// readImportPosts reads the posts of an archive in the format written by writeExportArchive.
+func readImportPosts(archive *zip.Reader) ([]ExportedPost, error) {
+	file, err := archive.Open("posts.json")
+	if err != nil {
+		return nil, err
+	}
+	defer func(file io.ReadCloser) {
+		_ = file.Close()
+	}(file)
+
+	var content struct {
+		Posts []ExportedPost `json:"posts"`
+	}
+	err = json.NewDecoder(file).Decode(&content)
+	return content.Posts, err
+}
+
+// importPost creates a post of the user uid from a post of an archive, with its original upload time, status, caption
+// and images. Each image goes through the same checks of uploadPost, and the caption through the ones of
+// setPostCaption. The post is identified by the hash of its id in the archive and its content, so that a post already
+// imported by the user is not created again. Function will return true if the post has been created. Errors caused by
+// the content of the archive wrap errImportRejected.
+func (rt *_router) importPost(uid uint64, post ExportedPost, archive *zip.Reader, context *reqcontext.RequestContext) (bool, error) {
+	if len(post.Images) == 0 || len(post.Images) > PostMaxMedia {
+		return false, fmt.Errorf("%w: a post must have from 1 to %d photos", errImportRejected, PostMaxMedia)
+	}
+
+	_, err := time.Parse("2006-01-02 15:04:05", post.Datetime)
+	if err != nil {
+		return false, fmt.Errorf("%w: not correct format for upload_datetime", errImportRejected)
+	}
+
+	// posts exported without a status are published
+	status := post.Status
+	if status == "" {
+		status = database.PostPublished
+	}
+	publishAt := ""
+	switch status {
+	case database.PostScheduled:
+		_, err = time.Parse("2006-01-02 15:04:05", post.PublishAt)
+		if err != nil {
+			return false, fmt.Errorf("%w: not correct format for publish_datetime", errImportRejected)
+		}
+		publishAt = post.PublishAt
+	case database.PostDraft, database.PostPublished:
+	default:
+		return false, fmt.Errorf("%w: status must be draft, scheduled or published", errImportRejected)
+	}
+
+	caption := Post{Caption: normalizeText(post.Caption)}
+	if !caption.IsValid() {
+		return false, fmt.Errorf("%w: caption is not valid", errImportRejected)
+	}
+
+	// the key is the hash of the id in the archive, the upload time, the caption and the images, so that identical
+	// posts of the same archive are imported as distinct posts
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%d\x00%s\x00%s\x00", post.Postid, post.Datetime, caption.Caption)
+
+	var images [][]byte
+	var media []database.Media
+	for _, name := range post.Images {
+		data, err := readImportImage(archive, name)
+		if err != nil {
+			return false, fmt.Errorf("%w: image %s cannot be read", errImportRejected, name)
+		}
+
+		imageType := checkImageType(data, mime.TypeByExtension(path.Ext(name)), context)
+		if imageType == "" {
+			return false, fmt.Errorf("%w: image %s is not supported", errImportRejected, name)
+		}
+
+		width, height, err := imageSize(bytes.NewReader(data))
+		if err != nil {
+			return false, fmt.Errorf("%w: image %s is not supported", errImportRejected, name)
+		}
+
+		imageHash := sha256.Sum256(data)
+		hash.Write(imageHash[:])
+		images = append(images, data)
+		media = append(media, database.Media{Format: imageType, Width: width, Height: height})
+	}
+
+	err = createDirs("media/img")
+	if err != nil {
+		return false, err
+	}
+
+	postid, created, err := rt.db.ImportPost(uid, database.ImportedPost{
+		Key:       hex.EncodeToString(hash.Sum(nil)),
+		Datetime:  post.Datetime,
+		Caption:   caption.Caption,
+		Tags:      extractHashtags(caption.Caption),
+		Media:     media,
+		Status:    status,
+		PublishAt: publishAt,
+	})
+	if err != nil || !created {
+		return false, err
+	}
+
+	// each image file is named after its media id
+	media, err = rt.db.GetPostMedia(postid)
+	if err == nil {
+		for i, item := range media {
+			filename := strconv.FormatUint(item.Mediaid, 10) + "." + item.Format
+			err = saveImage(bytes.NewReader(images[i]), "media/img", filename)
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		// Remove the incomplete post
+		_ = deleteMediaImages(media)
+		_, _ = rt.db.RemovePost(postid, uid)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// readImportImage reads an image of an archive, up to ImportMaxImageSize bytes.
+func readImportImage(archive *zip.Reader, name string) ([]byte, error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file io.ReadCloser) {
+		_ = file.Close()
+	}(file)
+
+	data, err := io.ReadAll(io.LimitReader(file, ImportMaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > ImportMaxImageSize {
+		return nil, errors.New("image too large")
+	}
+	return data, nil
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// importPosts allows a user to import the posts of an archive created by requestExport (the ZIP file is the request
+// body). Each post is created with its original upload time, caption and images; posts already imported by the user
+// are skipped, so that the same archive can be imported again safely. Posts that don't pass the checks of uploadPost
+// are rejected, and the other ones are imported anyway.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the body is not an archive with a valid posts.json file, the request will fail.
+func (rt *_router) importPosts(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in importing posts request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in importing posts request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes importing posts request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for importing posts request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in importing posts request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// a ZIP archive can't be read as a stream, so the body is copied to a temporary file
+	file, err := os.CreateTemp("", "import-*.zip")
+	if err != nil {
+		context.Logger.Error("Error creating temporary file in importing posts request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}(file)
+
+	size, err := io.Copy(file, http.MaxBytesReader(w, r.Body, ImportMaxSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		context.Logger.Error("Error in importing posts request! The archive is too large")
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+		response := map[string]string{
+			"error": "the archive is too large",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	} else if err != nil {
+		context.Logger.Error("Error reading archive in importing posts request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	archive, err := zip.NewReader(file, size)
+	var posts []ExportedPost
+	if err == nil {
+		posts, err = readImportPosts(archive)
+	}
+
+	if err != nil {
+		context.Logger.Error("Error in importing posts request! Not valid archive.\nDetail: ", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "the archive doesn't contain a valid posts.json file",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	result := ImportResult{Rejected: []ImportRejection{}}
+	for _, post := range posts {
+		created, err := rt.importPost(uid, post, archive, &context)
+		if errors.Is(err, errImportRejected) {
+			result.Rejected = append(result.Rejected, ImportRejection{Postid: post.Postid, Error: err.Error()})
+			continue
+		} else if err != nil {
+			context.Logger.Error("Error importing post.\nDetail: ", err.Error())
+			http.Error(w, "Something wrong importing your posts.", http.StatusInternalServerError)
+			return
+		}
+
+		if created {
+			result.Imported++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]ImportResult{"import": result})
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// likeComment allows a user to put like to a comment, that is the "heart" reaction (it replaces any other reaction of
+// the user to the comment).
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the post id or the comment id doesn't exist, the request will fail.
+// If the post owner has banned the user, the request will fail.
+// If the user cannot see the post (see canSeePost), the request will fail.
+// If the request is OK, it will return User{} object who has put the like.
+// Note: a user can put like to his own comment
+func (rt *_router) likeComment(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in put comment like request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in put comment like request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes put comment like request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for putting comment like request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in putting comment like request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in putting comment like request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for putting comment like!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in putting comment like request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Comment ID in the path is a 64-bit unsigned integer. Let's parse it.
+	commentid, err := strconv.ParseUint(params.ByName("commentid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing commentid in putting comment like request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for commentid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the comment is under the post
+	check, err = rt.db.CheckCommentOnPost(commentid, postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on commentid for putting comment like!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in putting comment like request! Comment doesn't exist")
+		http.Error(w, "Comment seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// Check if post owner has not banned the user
+	// First of all, retrieve the post owner
+	postDB, err := rt.db.GetPost(postid)
+
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in putting comment like request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	var postAPI Post
+	err = postAPI.FromDatabase(postDB)
+
+	if err != nil {
+		context.Logger.Error("Error converting PostDB to PostAPI in putting comment like request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	ownerid := postAPI.Uid
+	banned, err := rt.db.HasBanned(ownerid, uid)
+
+	if err != nil {
+		context.Logger.Error("Error retrieving ban information in putting comment like request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if banned {
+		context.Logger.Error("User is banned by post owner in putting comment like request!")
+		http.Error(w, "You cannot put like", http.StatusForbidden)
+		return
+	}
+
+	// check if the post is visible to the user
+	visible, err := rt.canSeePost(postDB, uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility in putting comment like request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Post is not visible to the user in putting comment like request!")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// Put the like on table
+	err = rt.db.LikeComment(postid, commentid, uid)
+
+	if err != nil {
+		context.Logger.Error("Error adding comment like to table.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong adding like to the comment.", http.StatusInternalServerError)
+		return
+	}
+
+	// Like correctly added
+	// Now return User{} struct
+	userdb, err := rt.db.GetUserByID(uid)
+
+	if err != nil {
+		context.Logger.Error("Error retrieving user structure in comment like request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong adding like to the comment.", http.StatusInternalServerError)
+		return
+	}
+
+	var userapi User
+	err = userapi.FromDatabase(userdb)
+
+	if err != nil {
+		context.Logger.Error("Error parsing using structure in comment like request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong adding like to the comment.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(userapi)
+
+}
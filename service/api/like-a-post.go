@@ -8,11 +8,13 @@ import (
 	"strconv"
 )
 
-// likePost allows a user to put like to a post.
+// likePost allows a user to put like to a post, that is the "heart" reaction (it replaces any other reaction of the
+// user to the post).
 // If the user is not authorized, the request will fail.
 // If the user id doesn't exist, the request will fail.
 // If the post id doesn't exist, the request will fail.
 // If the post owner has banned the user, the request will fail.
+// If the user cannot see the post (see canSeePost), the request will fail.
 // If the request is OK, it will return User{} object who has put the like.
 // Note: a user can put like to his own post
 func (rt *_router) likePost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
@@ -130,6 +132,20 @@ func (rt *_router) likePost(w http.ResponseWriter, r *http.Request, params httpr
 		return
 	}
 
+	// check if the post is visible to the user
+	visible, err := rt.canSeePost(postDB, uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility in putting like request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Post is not visible to the user in putting like request!")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
 	// Put the like on table
 	err = rt.db.LikePost(postid, uid)
 
@@ -0,0 +1,96 @@
+// Package api
+/* This file consists in all the function used to resolve @username mentions and to notify mentioned users */
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+)
+
+const (
+	// MentionRegex matches a '@' (not preceded by a letter, a digit, an underscore or another '@') followed by a username
+	MentionRegex string = "(?:^|[^\\p{L}\\p{M}\\p{N}_@])@([\\p{L}\\p{M}\\p{N}]+)"
+
+	// NotificationMention is the type of the notification sent to a user mentioned in a comment
+	NotificationMention string = "mention"
+)
+
+// resolveMentions finds the @username mentions inside a comment message (already NFC normalized) and resolves them
+// against the existing users at write time. Mentions of usernames that don't exist are ignored.
+// Offset and length of each mention are expressed in code points of the message.
+func (rt *_router) resolveMentions(message string) ([]database.Mention, error) {
+	regexPattern := regexp.MustCompile(MentionRegex)
+
+	var mentions []database.Mention
+	for _, match := range regexPattern.FindAllStringSubmatchIndex(message, -1) {
+		// match[2]:match[3] is the username, the '@' is right before it
+		username := message[match[2]:match[3]]
+		user, err := rt.db.GetUserByUsername(username)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		mentions = append(mentions, database.Mention{
+			Uid:      user.Userid,
+			Username: user.Username,
+			Offset:   uint64(utf8.RuneCountInString(message[:match[2]-1])),
+			Length:   uint64(utf8.RuneCountInString(message[match[2]-1 : match[3]])),
+		})
+	}
+	return mentions, nil
+}
+
+// notifyMentions sends a notification to each user mentioned in a comment under post, unless the user is the comment
+// author, he has banned the comment author or he cannot see the post (see canSeePost). Errors are logged, because the
+// comment has already been saved.
+func (rt *_router) notifyMentions(comment database.Comment, post database.Post, context reqcontext.RequestContext) {
+	notified := map[uint64]bool{}
+	for _, mention := range comment.Mentions {
+		if mention.Uid == comment.Userid || notified[mention.Uid] {
+			continue
+		}
+		notified[mention.Uid] = true
+
+		banned, err := rt.db.HasBanned(mention.Uid, comment.Userid)
+		if err != nil {
+			context.Logger.Warning("Error retrieving ban information for mention notification\nDetail: ", err.Error())
+			continue
+		}
+		if banned {
+			continue
+		}
+
+		role, err := rt.db.GetUserRole(mention.Uid)
+		if err != nil {
+			context.Logger.Warning("Error retrieving role for mention notification\nDetail: ", err.Error())
+			continue
+		}
+
+		visible, err := rt.canSeePost(post, mention.Uid, role)
+		if err != nil {
+			context.Logger.Warning("Error checking post visibility for mention notification\nDetail: ", err.Error())
+			continue
+		}
+		if !visible {
+			continue
+		}
+
+		err = rt.db.AddNotification(database.Notification{
+			Uid:       mention.Uid,
+			Type:      NotificationMention,
+			Actor:     comment.Userid,
+			Postid:    comment.Postid,
+			Commentid: comment.Commentid,
+		})
+		if err != nil {
+			context.Logger.Warning("Error adding mention notification\nDetail: ", err.Error())
+		}
+	}
+}
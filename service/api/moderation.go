@@ -0,0 +1,62 @@
+// Package api
+/* This file consists in all the function used to moderate the reported content and to write the audit trail */
+package api
+
+import (
+	"fmt"
+
+	"github.com/Simone0401/WASAPhoto/service/database"
+)
+
+const (
+	// ModerationHide is the action of the automatic hiding of a content that crossed the report threshold
+	ModerationHide string = "hide"
+	// ModerationResolve is the action of a moderator that accepts a report: the content stays hidden
+	ModerationResolve string = "resolve"
+	// ModerationDismiss is the action of a moderator that rejects a report: the content is shown again
+	ModerationDismiss string = "dismiss"
+	// ModerationDeletePost and ModerationDeleteComment are the actions of a moderator that deletes a content
+	ModerationDeletePost    string = "delete_post"
+	ModerationDeleteComment string = "delete_comment"
+	// ModerationSuspend and ModerationUnsuspend are the actions of an administrator that suspends a user (or lifts
+	// the suspension)
+	ModerationSuspend   string = "suspend"
+	ModerationUnsuspend string = "unsuspend"
+	// ModerationRename is the action of an administrator that forces a new username on a user
+	ModerationRename string = "rename"
+	// ModerationSetRole is the action of an administrator that changes the role of a user
+	ModerationSetRole string = "set_role"
+)
+
+// ReportReasons are the reason codes of a report
+var ReportReasons = []string{"spam", "nudity", "violence", "harassment", "hate_speech", "misinformation", "other"}
+
+// hideReportedContent hides a post (if commentid is 0) or a comment when its open reports cross the report threshold,
+// and writes the automatic action in the audit trail. Content already hidden is not changed.
+func (rt *_router) hideReportedContent(postid uint64, commentid uint64) error {
+	if rt.threshold == 0 {
+		return nil
+	}
+
+	reports, err := rt.db.CountOpenReports(postid, commentid)
+	if err != nil || reports < rt.threshold {
+		return err
+	}
+
+	hidden, err := rt.db.CheckContentHidden(postid, commentid)
+	if err != nil || hidden {
+		return err
+	}
+
+	err = rt.db.SetContentHidden(postid, commentid, true)
+	if err != nil {
+		return err
+	}
+
+	return rt.db.AddModerationAction(database.ModerationAction{
+		Action:    ModerationHide,
+		Postid:    postid,
+		Commentid: commentid,
+		Detail:    fmt.Sprintf("%d open reports", reports),
+	})
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// DefaultPageLimit is the number of items returned by a paginated API if the limit is not specified
+	DefaultPageLimit uint64 = 20
+	// MaxPageLimit is the maximum number of items that can be returned by a paginated API
+	MaxPageLimit uint64 = 100
+)
+
+// parsePagination allows to read the "offset" and "limit" query parameters of a paginated API.
+// If a parameter is missing the default value is used (offset 0, limit DefaultPageLimit).
+// Function will return an error if a parameter is not a valid number or if the limit is not in [1, MaxPageLimit].
+func parsePagination(r *http.Request) (uint64, uint64, error) {
+	offset := uint64(0)
+	limit := DefaultPageLimit
+
+	var err error
+	if value := r.URL.Query().Get("offset"); value != "" {
+		offset, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if limit == 0 || limit > MaxPageLimit {
+		return 0, 0, errors.New("limit out of range")
+	}
+
+	return offset, limit, nil
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// PinnedPostsMax is the maximum number of posts a user can pin to the top of his profile
+	PinnedPostsMax uint64 = 3
+)
+
+// pinPost allows a user to pin one of his published posts to the top of his profile: pinned posts are returned by
+// getUserProfile before the other posts, the last pinned first. Pinning a post already pinned has no effect.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the post id doesn't exist, the request will fail.
+// If the user is not the post author, the request will fail.
+// If the post is not published, it's archived or the user has already pinned PinnedPostsMax posts, the request will
+// fail.
+func (rt *_router) pinPost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in pinning post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in pinning post request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes pinning post request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for pinning post request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in pinning post request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in pinning post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for pinning post!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in pinning post request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// check if the user is the post author
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in pinning post request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if postDB.Uid != uid {
+		context.Logger.Error("User is not the owner of the post in pinning post request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// drafts and scheduled posts are not shown in the profile
+	if postDB.Status != database.PostPublished {
+		context.Logger.Error("Error in pinning post request! Post is not published")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "the post is not published",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// archived posts are not shown in the profile
+	if postDB.Archived {
+		context.Logger.Error("Error in pinning post request! Post is archived")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "the post is archived",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	pinned, err := rt.db.PinPost(uid, postid, PinnedPostsMax)
+	if err != nil {
+		context.Logger.Error("Error pinning post.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong pinning the post.", http.StatusInternalServerError)
+		return
+	}
+
+	if !pinned {
+		context.Logger.Error("Error in pinning post request! Too many pinned posts")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": fmt.Sprintf("at most %d posts can be pinned", PinnedPostsMax),
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
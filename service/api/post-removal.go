@@ -0,0 +1,23 @@
+package api
+
+import (
+	"fmt"
+)
+
+// removePost removes a post of the user uid together with its comments, hashtags, reactions and images. The images
+// that cannot be removed are logged, as the post is already deleted.
+// Function will return nil if no errors are present, an error otherwise.
+func (rt *_router) removePost(postid uint64, uid uint64) error {
+	// Remove post from tables
+	media, err := rt.db.RemovePost(postid, uid)
+	if err != nil {
+		return fmt.Errorf("deleting post from table: %w", err)
+	}
+
+	// Remove the images of the carousel from folder
+	err = deleteMediaImages(media)
+	if err != nil {
+		rt.baseLogger.WithError(err).Errorf("error removing images of post %d", postid)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"time"
+)
+
+const (
+	// PostSchedulerInterval is how often the scheduled posts are published
+	PostSchedulerInterval = time.Minute
+	// NotificationPublished is the type of the notification sent to a user when his scheduled post is published
+	NotificationPublished string = "published"
+)
+
+// parsePublication checks the status requested for a post and its publish time (in the format of the timestamps
+// shown to the users), and it returns the status with the time to pass to the database: the publish time of a
+// scheduled post, or globaltime.Now() for a post published at once. An empty status means a scheduled post if the
+// publish time is set, a published post otherwise.
+// Function will return an error, which can be shown to the user, if the publication is not valid.
+func parsePublication(status string, publishAt string) (string, time.Time, error) {
+	if status == "" && publishAt != "" {
+		status = database.PostScheduled
+	} else if status == "" {
+		status = database.PostPublished
+	}
+
+	switch status {
+	case database.PostScheduled:
+		at, err := database.ParseTime(publishAt)
+		if err != nil {
+			return "", time.Time{}, errors.New("not correct format for publish_datetime")
+		}
+		if !at.After(globaltime.Now()) {
+			return "", time.Time{}, errors.New("publish_datetime must be in the future")
+		}
+		return status, at, nil
+	case database.PostDraft, database.PostPublished:
+		if publishAt != "" {
+			return "", time.Time{}, errors.New("publish_datetime is allowed only for scheduled posts")
+		}
+		return status, globaltime.Now(), nil
+	default:
+		return "", time.Time{}, errors.New("status must be draft, scheduled or published")
+	}
+}
+
+// startPostScheduler starts the background goroutine that publishes the scheduled posts every
+// PostSchedulerInterval. The goroutine is stopped by Close.
+func (rt *_router) startPostScheduler() {
+	rt.schedulerStop = make(chan struct{})
+	rt.schedulerDone = make(chan struct{})
+
+	go func() {
+		defer close(rt.schedulerDone)
+
+		ticker := time.NewTicker(PostSchedulerInterval)
+		defer ticker.Stop()
+
+		for {
+			rt.publishScheduledPosts()
+
+			select {
+			case <-rt.schedulerStop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stopPostScheduler stops the goroutine started by startPostScheduler and waits for it to exit.
+func (rt *_router) stopPostScheduler() {
+	close(rt.schedulerStop)
+	<-rt.schedulerDone
+}
+
+// publishScheduledPosts publishes the posts scheduled before globaltime.Now(), and it notifies their owners. Errors are
+// logged, and the posts not published are tried again at the next run.
+func (rt *_router) publishScheduledPosts() {
+	now := globaltime.Now()
+	posts, err := rt.db.GetScheduledPosts(now)
+	if err != nil {
+		rt.baseLogger.WithError(err).Error("error retrieving scheduled posts")
+		return
+	}
+
+	for _, post := range posts {
+		err = rt.db.SetPostPublication(post.Postid, database.PostPublished, now)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error publishing scheduled post %d", post.Postid)
+			continue
+		}
+
+		err = rt.db.AddNotification(database.Notification{
+			Uid:    post.Uid,
+			Type:   NotificationPublished,
+			Actor:  post.Uid,
+			Postid: post.Postid,
+		})
+		if err != nil {
+			rt.baseLogger.WithError(err).Warnf("error adding notification of published post %d", post.Postid)
+		}
+	}
+}
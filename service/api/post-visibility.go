@@ -0,0 +1,32 @@
+package api
+
+import "github.com/Simone0401/WASAPhoto/service/database"
+
+// canSeePost checks if uid (with the specified role) can see a post, and so interact with it: the owner always can,
+// the other users only if the post is published and not archived, it's not hidden by the moderation (the moderators
+// can see it anyway) and there is no ban between them and the owner.
+func (rt *_router) canSeePost(post database.Post, uid uint64, role string) (bool, error) {
+	if post.Uid == uid {
+		return true, nil
+	}
+
+	if post.Status != database.PostPublished || post.Archived {
+		return false, nil
+	}
+
+	hidden, err := rt.db.CheckContentHidden(post.Postid, 0)
+	if err != nil || (hidden && !canModerate(role)) {
+		return false, err
+	}
+
+	banned, err := rt.db.HasBanned(post.Uid, uid)
+	if err != nil || banned {
+		return false, err
+	}
+
+	muted, err := rt.db.HasBanned(uid, post.Uid)
+	if err != nil {
+		return false, err
+	}
+	return !muted, nil
+}
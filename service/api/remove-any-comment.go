@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// removeAnyComment allows a moderator to delete the comment of any user. The deletion is written in the audit trail.
+// If the user is not authorized or he is not a moderator, the request will fail.
+// If the comment id doesn't exist, the request will fail.
+func (rt *_router) removeAnyComment(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in removing any comment request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is a moderator
+	if !canModerate(context.Role) {
+		context.Logger.Error("The user that makes removing any comment request is not a moderator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The Comment ID in the path is a 64-bit unsigned integer. Let's parse it.
+	commentid, err := strconv.ParseUint(params.ByName("commentid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing commentid in removing any comment request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for commentid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the comment exists
+	check, err := rt.db.CheckCommentByCommentid(commentid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on commentid for removing any comment!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in removing any comment request! Comment doesn't exist")
+		http.Error(w, "Comment seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// Delete comment from table
+	err = rt.db.DeleteComment(commentid)
+	if err != nil {
+		context.Logger.Error("Error deleting comment from table in removing any comment request\nDetail: ", err.Error())
+		http.Error(w, "Something wrong deleting the comment", http.StatusInternalServerError)
+		return
+	}
+
+	err = rt.db.AddModerationAction(database.ModerationAction{
+		Actor:     context.Uid,
+		Action:    ModerationDeleteComment,
+		Commentid: commentid,
+	})
+	if err != nil {
+		context.Logger.Error("Error writing audit trail in removing any comment request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong deleting the comment", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
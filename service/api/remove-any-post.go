@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// removeAnyPost allows a moderator to delete the post of any user, together with its comments and images. The
+// deletion is written in the audit trail.
+// If the user is not authorized or he is not a moderator, the request will fail.
+// If the post id doesn't exist, the request will fail.
+func (rt *_router) removeAnyPost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in removing any post request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is a moderator
+	if !canModerate(context.Role) {
+		context.Logger.Error("The user that makes removing any post request is not a moderator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in removing any post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err := rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for removing any post!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in removing any post request! Post doesn't exist")
+		http.Error(w, "Post seems not exist", http.StatusNotFound)
+		return
+	}
+
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in removing any post request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	// Remove the post with its comments, hashtags, reactions and images
+	err = rt.removePost(postid, postDB.Uid)
+	if err != nil {
+		context.Logger.Error("Error removing post in removing any post request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong removing post", http.StatusInternalServerError)
+		return
+	}
+
+	err = rt.db.AddModerationAction(database.ModerationAction{
+		Actor:  context.Uid,
+		Action: ModerationDeletePost,
+		Uid:    postDB.Uid,
+		Postid: postid,
+	})
+	if err != nil {
+		context.Logger.Error("Error writing audit trail in removing any post request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong removing post", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// removeReaction allows a user to remove his reaction to a post, or to one of its comments if the comment id is in the
+// path. If the user has not reacted, nothing happens.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the post id (or the comment id) doesn't exist, the request will fail.
+func (rt *_router) removeReaction(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in removing reaction request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in removing reaction request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes removing reaction request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for removing reaction request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in removing reaction request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in removing reaction request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for removing reaction!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in removing reaction request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Comment ID is in the path only for the reactions to a comment (0 means the post itself)
+	var commentid uint64
+	if params.ByName("commentid") != "" {
+		commentid, err = strconv.ParseUint(params.ByName("commentid"), 10, 64)
+
+		if err != nil {
+			context.Logger.Error("Error parsing commentid in removing reaction request")
+			w.WriteHeader(http.StatusBadRequest)
+
+			response := map[string]string{
+				"error": "not correct format for commentid",
+			}
+
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		// check if the comment is under the post
+		check, err = rt.db.CheckCommentOnPost(commentid, postid)
+		if err != nil {
+			context.Logger.Error("Error retrieving information on commentid for removing reaction!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+			return
+		}
+
+		if !check {
+			context.Logger.Error("Error in removing reaction request! Comment doesn't exist")
+			http.Error(w, "Comment seems not exist.", http.StatusNotFound)
+			return
+		}
+	}
+
+	err = rt.db.RemoveReaction(postid, commentid, uid)
+	if err != nil {
+		context.Logger.Error("Error removing reaction from table.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong removing the reaction.", http.StatusInternalServerError)
+		return
+	}
+
+	// Reaction correctly removed
+	// Now return 204 status
+	w.WriteHeader(http.StatusNoContent)
+}
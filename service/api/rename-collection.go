@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// renameCollection allows a user to set a new name for one of his collections of saved posts.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the collection id doesn't exist or the collection is owned by another user, the request will fail.
+// If the name is not valid, the request will fail.
+// If the user already has another collection with the same name, the request will fail.
+// If the request is OK, it will return the updated Collection{} object.
+func (rt *_router) renameCollection(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in renaming collection request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in renaming collection request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes renaming collection request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for renaming collection request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in renaming collection request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Collection ID in the path is a 64-bit unsigned integer. Let's parse it.
+	collectionid, err := strconv.ParseUint(params.ByName("collectionid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing collectionid in renaming collection request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for collectionid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the collection exists and the user is its owner
+	check, err = rt.db.CheckCollectionOwner(collectionid, uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving collection information in renaming collection request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in renaming collection request! Collection doesn't exist")
+		http.Error(w, "Collection seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	var collection Collection
+	err = json.NewDecoder(r.Body).Decode(&collection)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	collection.Name = normalizeText(strings.TrimSpace(collection.Name))
+	if !collection.IsValid() {
+		context.Logger.Error("Name for collection is not valid!")
+		http.Error(w, "Your collection cannot be renamed. Check its name!", http.StatusBadRequest)
+		return
+	}
+
+	// check if the user already has a collection with the same name
+	check, err = rt.db.CheckExistsCollectionName(uid, collection.Name)
+	if err != nil {
+		context.Logger.Error("Error retrieving collection information in renaming collection request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	collectionDB, err := rt.db.GetCollection(collectionid)
+	if err != nil {
+		context.Logger.Error("Error retrieving collection in renaming collection request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if check && collectionDB.Name != collection.Name {
+		context.Logger.Error("Error in renaming collection request! Name already used")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "you already have a collection with this name",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	err = rt.db.RenameCollection(collectionid, collection.Name)
+	if err != nil {
+		context.Logger.Error("Error renaming collection.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong renaming your collection.", http.StatusInternalServerError)
+		return
+	}
+
+	collectionDB, err = rt.db.GetCollection(collectionid)
+	if err != nil {
+		context.Logger.Error("Error retrieving renamed collection.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong renaming your collection.", http.StatusInternalServerError)
+		return
+	}
+
+	err = collection.FromDatabase(collectionDB)
+	if err != nil {
+		context.Logger.Error("Error parsing collection structure in renaming collection request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong renaming your collection.", http.StatusInternalServerError)
+		return
+	}
+	collection.Datetime, _ = formatDatetime(collection.Datetime)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Collection{"collection": collection})
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// reportContent allows a user to report a post, or one of its comments if the comment id is in the path, to the
+// moderators. The body contains the reason code, one of the ReportReasons. When the open reports of the content cross
+// the report threshold, the content is hidden until a moderator reviews it.
+// If the user is not authorized, the request will fail.
+// If the post id (or the comment id) doesn't exist, the request will fail.
+// If the user cannot see the post (see canSeePost), the request will fail.
+// If the content is of the user, the request will fail.
+// If the user has already reported the content, the request will fail.
+// If the reason is not valid, the request will fail.
+// If the request is OK, it will return the created Report{} object.
+func (rt *_router) reportContent(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in reporting content request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the user exists
+	uid := context.Uid
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for reporting content request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in reporting content request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in reporting content request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for reporting content!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in reporting content request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in reporting content request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	// The Comment ID is in the path only for the reports of a comment (0 means the post itself)
+	var commentid uint64
+	owned := postDB.Uid == uid
+	if params.ByName("commentid") != "" {
+		commentid, err = strconv.ParseUint(params.ByName("commentid"), 10, 64)
+
+		if err != nil {
+			context.Logger.Error("Error parsing commentid in reporting content request")
+			w.WriteHeader(http.StatusBadRequest)
+
+			response := map[string]string{
+				"error": "not correct format for commentid",
+			}
+
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		// check if the comment is under the post
+		check, err = rt.db.CheckCommentOnPost(commentid, postid)
+		if err != nil {
+			context.Logger.Error("Error retrieving information on commentid for reporting content!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+			return
+		}
+
+		if !check {
+			context.Logger.Error("Error in reporting content request! Comment doesn't exist")
+			http.Error(w, "Comment seems not exist.", http.StatusNotFound)
+			return
+		}
+
+		owned, err = rt.db.CheckCommentOwner(commentid, uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving comment owner in reporting content request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// check if the post is visible to the user
+	visible, err := rt.canSeePost(postDB, uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility in reporting content request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Post is not visible to the user in reporting content request!")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	if owned {
+		context.Logger.Error("Error in reporting content request! User cannot report his own content")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "you cannot report your own content",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	var report Report
+	err = json.NewDecoder(r.Body).Decode(&report)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !report.IsValid() {
+		context.Logger.Error("Report reason is not valid!")
+		http.Error(w, "Report reason is not valid", http.StatusBadRequest)
+		return
+	}
+
+	// check if the user has already reported the content
+	check, err = rt.db.CheckReport(uid, postid, commentid)
+	if err != nil {
+		context.Logger.Error("Error retrieving report information in reporting content request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if check {
+		context.Logger.Error("Error in reporting content request! Content already reported")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "you have already reported this content",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	reportDB, err := rt.db.AddReport(database.Report{
+		Reporter:  database.User{Userid: uid},
+		Postid:    postid,
+		Commentid: commentid,
+		Reason:    report.Reason,
+	})
+	if err != nil {
+		context.Logger.Error("Error adding report.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong reporting the content.", http.StatusInternalServerError)
+		return
+	}
+
+	// The report is stored even if the content cannot be hidden now: the moderators will review it anyway
+	err = rt.hideReportedContent(postid, commentid)
+	if err != nil {
+		context.Logger.Error("Error hiding reported content.\nDetail: ", err.Error())
+	}
+
+	err = report.FromDatabase(reportDB)
+	if err != nil {
+		context.Logger.Error("Error parsing report structure in reporting content request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong reporting the content.", http.StatusInternalServerError)
+		return
+	}
+	// The number of reports is returned only to the moderators
+	report.Reports = 0
+	report.Datetime, _ = formatDatetime(report.Datetime)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]Report{"report": report})
+}
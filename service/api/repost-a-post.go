@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// repostPost allows a user to repost the post of another user, with an optional quote in the "quote" field of the
+// body. The repost appears in the streams of his followers. If the post is already reposted, only the quote changes.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the post id doesn't exist, the request will fail.
+// If the post is of the user, the request will fail.
+// If the user cannot see the post (see canSeePost), the request will fail.
+// If the quote is not valid, the request will fail.
+// If the request is OK, it will return the Repost{} object.
+func (rt *_router) repostPost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in reposting request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in reposting request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes reposting request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for reposting request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in reposting request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in reposting request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for reposting!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in reposting request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// check if the post is visible to the user
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in reposting request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	visible, err := rt.canSeePost(postDB, uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility in reposting request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Post is not visible to the user in reposting request!")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	if postDB.Uid == uid {
+		context.Logger.Error("Error in reposting request! User cannot repost his own post")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "you cannot repost your own post",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// The body is optional and it can contain the quote of the repost
+	var repost Repost
+	err = json.NewDecoder(r.Body).Decode(&repost)
+	if err != nil && !errors.Is(err, io.EOF) {
+		// The body was not a parseable JSON, reject it
+		context.Logger.Error("Error parsing body in reposting request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for quote",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	repost.Quote = normalizeText(strings.TrimSpace(repost.Quote))
+	if !repost.IsValid() {
+		context.Logger.Error("Quote for repost is not valid!")
+		http.Error(w, "Your repost cannot be published. Check its quote!", http.StatusBadRequest)
+		return
+	}
+
+	repostDB, err := rt.db.SetRepost(uid, postid, repost.Quote)
+	if err != nil {
+		context.Logger.Error("Error reposting post.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong reposting the post.", http.StatusInternalServerError)
+		return
+	}
+
+	err = repost.FromDatabase(repostDB)
+	if err != nil {
+		context.Logger.Error("Error parsing repost structure in reposting request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong reposting the post.", http.StatusInternalServerError)
+		return
+	}
+	repost.Datetime, _ = formatDatetime(repost.Datetime)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Repost{"repost": repost})
+}
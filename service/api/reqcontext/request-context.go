@@ -19,6 +19,9 @@ type RequestContext struct {
 	// Uid is the user unique ID
 	Uid uint64
 
+	// Role is the role of the user (user, moderator or admin)
+	Role string
+
 	// Logger is a custom field logger for the request
 	Logger logrus.FieldLogger
 }
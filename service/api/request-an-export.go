@@ -0,0 +1,105 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// requestExport allows a user to request an archive with all his personal data: his profile, posts (with their
+// original images), comments, reactions, follows and bans. The archive is built in background; its status and download
+// link are returned by getExport.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the last export of the user is still pending, the request will fail.
+func (rt *_router) requestExport(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in requesting export request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in requesting export request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes requesting export request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for requesting export request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in requesting export request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// only one export at a time can be pending
+	last, err := rt.db.GetLastExport(uid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		context.Logger.Error("Error retrieving last export in requesting export request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if err == nil && last.Status == database.ExportPending {
+		context.Logger.Error("Error in requesting export request! An export is already pending")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "your last export is still being prepared",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	exportDB, err := rt.queueExport(uid)
+	if err != nil {
+		context.Logger.Error("Error queuing export.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong requesting your export.", http.StatusInternalServerError)
+		return
+	}
+
+	var export Export
+	_ = export.FromDatabase(exportDB)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]Export{"export": export})
+}
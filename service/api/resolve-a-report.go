@@ -0,0 +1,13 @@
+package api
+
+import (
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// resolveReport allows a moderator to accept a report: the reported content is hidden to the users and all its open
+// reports are resolved. See closeReport for details.
+func (rt *_router) resolveReport(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	rt.closeReport(w, r, params, context, ModerationResolve)
+}
@@ -0,0 +1,90 @@
+// Package api
+/* This file consists in all the function used to manage the roles and the status of the users */
+package api
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+)
+
+// Roles are the roles that a user can have
+var Roles = []string{database.RoleUser, database.RoleModerator, database.RoleAdmin}
+
+// canModerate checks if a role allows to review the reports and to delete any content.
+func canModerate(role string) bool {
+	return role == database.RoleModerator || role == database.RoleAdmin
+}
+
+// isConfiguredAdmin checks if the user is one of the administrators in the configuration.
+func (rt *_router) isConfiguredAdmin(uid uint64) bool {
+	for _, admin := range rt.admins {
+		if admin == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// getUserAccess returns the role and the current status of a user. The administrators in the configuration are
+// always administrators, even if they have been created after the start of the server. Users that don't exist are
+// returned as active users, so that the handlers can reply as usual.
+func (rt *_router) getUserAccess(uid uint64) (string, database.UserStatus, error) {
+	role, err := rt.db.GetUserRole(uid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.RoleUser, database.UserStatus{Status: database.StatusActive}, nil
+	} else if err != nil {
+		return "", database.UserStatus{}, err
+	}
+
+	if rt.isConfiguredAdmin(uid) {
+		role = database.RoleAdmin
+	}
+
+	status, err := rt.db.GetUserStatus(uid, globaltime.Now())
+	return role, status, err
+}
+
+// bootstrapAdmins gives the administrator role to the existing users in the configuration. Users that don't exist
+// yet are skipped: they will be administrators when they are created.
+func (rt *_router) bootstrapAdmins() {
+	for _, uid := range rt.admins {
+		exists, err := rt.db.CheckExistsByUID(uid)
+		if err != nil {
+			rt.baseLogger.WithError(err).Error("can't check the administrator in the configuration")
+			continue
+		}
+		if !exists {
+			rt.baseLogger.Warnf("administrator %d in the configuration doesn't exist yet", uid)
+			continue
+		}
+
+		err = rt.db.SetUserRole(uid, database.RoleAdmin)
+		if err != nil {
+			rt.baseLogger.WithError(err).Error("can't set the administrator role")
+		}
+	}
+}
+
+// getAccount returns the role and the current status of a user.
+// Function will fail if the user doesn't exist.
+func (rt *_router) getAccount(uid uint64) (Account, error) {
+	var account Account
+	userDB, err := rt.db.GetUserByID(uid)
+	if err != nil {
+		return account, err
+	}
+	_ = account.User.FromDatabase(userDB)
+
+	role, status, err := rt.getUserAccess(uid)
+	if err != nil {
+		return account, err
+	}
+	account.Role = role
+	account.Status = status.Status
+	account.Reason = status.Reason
+	account.Until, _ = formatDatetime(status.Until)
+	return account, nil
+}
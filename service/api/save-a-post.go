@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// savePost allows a user to save a post privately, optionally in one of his collections passing its id in the
+// "collectionid" field of the body. If the post is already saved, it's moved to the specified collection.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the post id doesn't exist, the request will fail.
+// If the user cannot see the post (see canSeePost), the request will fail.
+// If the collection is not one of the user's collections, the request will fail.
+func (rt *_router) savePost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in saving post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in saving post request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes saving post request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for saving post request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in saving post request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in saving post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for saving post!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in saving post request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// check if the post is visible to the user
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in saving post request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	visible, err := rt.canSeePost(postDB, uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility in saving post request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Post is not visible to the user in saving post request!")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The body is optional and it can contain the collection where the post is saved
+	var saved struct {
+		Collectionid uint64 `json:"collectionid"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&saved)
+	if err != nil && !errors.Is(err, io.EOF) {
+		// The body was not a parseable JSON, reject it
+		context.Logger.Error("Error parsing body in saving post request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for collectionid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	if saved.Collectionid != 0 {
+		check, err = rt.db.CheckCollectionOwner(saved.Collectionid, uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving collection information in saving post request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+			return
+		}
+
+		if !check {
+			context.Logger.Error("Error in saving post request! Collection doesn't exist")
+			http.Error(w, "Collection seems not exist.", http.StatusNotFound)
+			return
+		}
+	}
+
+	err = rt.db.SavePost(uid, postid, saved.Collectionid)
+	if err != nil {
+		context.Logger.Error("Error saving post.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong saving the post.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
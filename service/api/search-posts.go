@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// SearchMaxRunes is the maximum length of the text searched in the posts
+	SearchMaxRunes int = 256
+)
+
+// searchPosts allows searching the posts whose caption or comments contain the text passed in the "q" query
+// parameter. Words between double quotes are searched as a phrase, and words ending with "*" as a prefix.
+// If the user is not authorized, the request will fail.
+// If the text is empty or too long, the request will fail.
+// Posts of users that have banned the current user (or banned by him) are not returned. Each post is returned with a
+// snippet of the matching text, where the matched words are highlighted.
+// The list is paginated with the "offset" and "limit" query parameters, in reverse chronological order.
+func (rt *_router) searchPosts(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in searching posts request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	check, err := rt.db.CheckExistsByUID(context.Uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID that makes searching posts request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in searching posts request! User that makes request doesn't exist!")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The searched text is in the query. Let's normalize it.
+	text := normalizeText(strings.TrimSpace(r.URL.Query().Get("q")))
+	if text == "" || utf8.RuneCountInString(text) > SearchMaxRunes {
+		context.Logger.Error("Error parsing text in searching posts request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for q",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in searching posts request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Prepare return struct
+	results := map[string][]PostMatch{
+		"results": {},
+	}
+
+	listMatch, err := rt.db.SearchPosts(text, context.Uid, offset, limit)
+	if err != nil {
+		context.Logger.Error("Error searching posts during searching posts request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong searching posts", http.StatusInternalServerError)
+		return
+	}
+
+	// Append each post to the list
+	for i, match := range listMatch {
+		var matchAPI PostMatch
+		err = matchAPI.FromDatabase(match)
+		if err != nil {
+			mess := fmt.Sprintf("Error parsing matchDB to matchAPI for post number %d in searching posts request\nDetail: ", i)
+			context.Logger.Error(mess, err.Error())
+			http.Error(w, "Something wrong searching posts", http.StatusInternalServerError)
+			return
+		}
+		err = rt.markLikedComments(&matchAPI.Post, context.Uid)
+		if err != nil {
+			context.Logger.Error("Error retrieving liked comments in searching posts request!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong searching posts", http.StatusInternalServerError)
+			return
+		}
+		// Change datetime format for each comment
+		for i := 0; i < len(matchAPI.Post.Comments); i++ {
+			matchAPI.Post.Comments[i].Datetime, _ = formatDatetime(matchAPI.Post.Comments[i].Datetime)
+		}
+		matchAPI.Post.Datetime, _ = formatDatetime(matchAPI.Post.Datetime)
+		results["results"] = append(results["results"], matchAPI)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(results)
+}
@@ -8,10 +8,14 @@ import (
 	"net/http"
 )
 
-// getUsers allows searching for users' profile information passing a username (or part of it).
+// getUsers allows searching for users' profile information passing a username (or part of it, even with typos).
+// Results are ranked by similarity with the search text, boosting the users followed by the current user and the
+// users followed by the people he follows.
 // If the user is not authorized, the request will fail.
 // If the user id doesn't exist, the request will fail.
-// The return values will be the user's profile information for each user that matches the search criteria.
+// The return values will be the user's profile information for each user that matches the search criteria, except
+// the users that have banned the current user.
+// Results are paginated with the "offset" and "limit" query parameters.
 func (rt *_router) getUsers(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
 
 	// check if the Bearer Authorization Token is set
@@ -42,13 +46,27 @@ func (rt *_router) getUsers(w http.ResponseWriter, r *http.Request, params httpr
 		return
 	}
 
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		context.Logger.Error("Error parsing pagination in getting profiles request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for offset or limit",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	// The User username (or part of it) in the query is a string. Let's parse it.
-	username := r.URL.Query().Get("search")
+	username := normalizeText(r.URL.Query().Get("search"))
 
 	// Prepare return statement
-	var users []User
+	users := []User{}
 
-	usersDb, err := rt.db.SearchUserByUsername(username)
+	// Users that have banned the current user are not returned by the search
+	usersDb, err := rt.db.SearchUserByUsername(username, context.Uid, offset, limit)
 	if err != nil {
 		context.Logger.Error("Error getting []Users from username in getting profiles request!\nDetail: ", err.Error())
 		http.Error(w, "Something wrong retrieving user profiles", http.StatusInternalServerError)
@@ -57,27 +75,15 @@ func (rt *_router) getUsers(w http.ResponseWriter, r *http.Request, params httpr
 
 	// Append each user to the list of users
 	for i, user := range usersDb {
-
-		// Check if the user is banned
-		banned, err := rt.db.HasBanned(user.Userid, context.Uid)
+		var userAPI User
+		err = userAPI.FromDatabase(user)
 		if err != nil {
-			mess := fmt.Sprintf("Error getting ban information for the user number %d in getting users request\nDetail: ", i)
+			mess := fmt.Sprintf("Error parsing userDB to userAPI for user number %d in getting users request\nDetail: ", i)
 			context.Logger.Error(mess, err.Error())
 			http.Error(w, "Something wrong retrieving profiles", http.StatusInternalServerError)
 			return
 		}
-
-		if !banned {
-			var userAPI User
-			err = userAPI.FromDatabase(user)
-			if err != nil {
-				mess := fmt.Sprintf("Error parsing userDB to userAPI for user number %d in getting users request\nDetail: ", i)
-				context.Logger.Error(mess, err.Error())
-				http.Error(w, "Something wrong retrieving profiles", http.StatusInternalServerError)
-				return
-			}
-			users = append(users, userAPI)
-		}
+		users = append(users, userAPI)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
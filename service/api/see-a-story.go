@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// seeStory allows a user to mark a story as seen. If the story is already seen, nothing happens.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the story doesn't exist or it's expired, the request will fail.
+// If the user doesn't follow the story owner (or there is a ban between them), the request will fail.
+// The owner's views of his stories are not recorded.
+func (rt *_router) seeStory(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in seeing story request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in seeing story request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes seeing story request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for seeing story request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in seeing story request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Story ID in the path is a 64-bit unsigned integer. Let's parse it.
+	storyid, err := strconv.ParseUint(params.ByName("storyid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing storyid in seeing story request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for storyid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the story exists and it's not expired
+	now := globaltime.Now()
+	check, err = rt.db.CheckStoryByStoryid(storyid, now)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on storyid for seeing story!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in seeing story request! Story doesn't exist")
+		http.Error(w, "Story seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	storyDB, err := rt.db.GetStory(storyid)
+	if err != nil {
+		context.Logger.Error("Error retrieving story information in seeing story request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	// check if the story is visible to the user
+	check, err = rt.canSeeStory(storyDB, uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving follow and ban information in seeing story request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Story is not visible to the user in seeing story request!")
+		http.Error(w, "Story seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	if storyDB.Uid != uid {
+		err = rt.db.SetStorySeen(storyid, uid, now)
+		if err != nil {
+			context.Logger.Error("Error marking story as seen.\nDetail: ", err.Error())
+			http.Error(w, "Something wrong seeing the story.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
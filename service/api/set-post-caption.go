@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// setPostCaption allows setting (or editing) the caption of a post, if the user is the post author.
+// If the user id doesn't exist, the request will fail.
+// If the user in not authorized, the request will fail.
+// If the post id doesn't exist, the request will fail.
+// If the caption is not well formatted, the request will fail.
+// The hashtags in the caption are re-indexed. If the request is OK, it will return the updated Post{} object.
+func (rt *_router) setPostCaption(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in setting caption request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in setting caption request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes setting caption request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in setting caption request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err := rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for setting caption!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in setting caption request! Post doesn't exist")
+		http.Error(w, "Post seems not exist", http.StatusNotFound)
+		return
+	}
+
+	// check if the user is the post author
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in setting caption request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if postDB.Uid != uid {
+		context.Logger.Error("User is not the owner of the post in setting caption request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var post Post
+	err = json.NewDecoder(r.Body).Decode(&post)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	post.Caption = normalizeText(post.Caption)
+	if !post.IsValid() {
+		context.Logger.Error("Caption for post is not valid!")
+		http.Error(w, "Your caption cannot be uploaded. Check its format!", http.StatusBadRequest)
+		return
+	}
+
+	// Update the caption and its hashtags
+	err = rt.db.SetPostCaption(postid, post.Caption, extractHashtags(post.Caption))
+	if err != nil {
+		context.Logger.Error("Error updating post caption\nDetail: ", err.Error())
+		http.Error(w, "Something wrong updating your caption", http.StatusInternalServerError)
+		return
+	}
+
+	// Caption correctly updated, return the post
+	postDB, err = rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving updated post in setting caption request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong updating your caption", http.StatusInternalServerError)
+		return
+	}
+
+	post = Post{}
+	err = post.FromDatabase(postDB)
+	if err != nil {
+		context.Logger.Error("Error converting PostDB to PostAPI in setting caption request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong updating your caption", http.StatusInternalServerError)
+		return
+	}
+
+	// Change datetime format for each comment
+	for i := 0; i < len(post.Comments); i++ {
+		post.Comments[i].Datetime, _ = formatDatetime(post.Comments[i].Datetime)
+	}
+	post.Datetime, _ = formatDatetime(post.Datetime)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Post{"post": post})
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// setPostPublication allows the post author to publish a draft or a scheduled post at once, to schedule it for a later
+// time (the scheduler publishes it and notifies the author), or to turn it back into a draft. A published post
+// appears in the streams with the time of its publication.
+// If the user id doesn't exist, the request will fail.
+// If the user in not authorized, the request will fail.
+// If the post id doesn't exist, the request will fail.
+// If the post is already published, the request will fail.
+// If the request is OK, it will return the updated Post{} object.
+func (rt *_router) setPostPublication(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in setting publication request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in setting publication request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes setting publication request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in setting publication request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err := rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for setting publication!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in setting publication request! Post doesn't exist")
+		http.Error(w, "Post seems not exist", http.StatusNotFound)
+		return
+	}
+
+	// check if the user is the post author
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in setting publication request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if postDB.Uid != uid {
+		context.Logger.Error("User is not the owner of the post in setting publication request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// a published post cannot go back
+	if postDB.Status == database.PostPublished {
+		context.Logger.Error("Error in setting publication request! Post is already published")
+		w.WriteHeader(http.StatusConflict)
+
+		response := map[string]string{
+			"error": "the post is already published",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	var publication Publication
+	err = json.NewDecoder(r.Body).Decode(&publication)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	status, at, err := parsePublication(publication.Status, publication.PublishAt)
+	if err != nil {
+		context.Logger.Error("Error in setting publication request! Not valid publication")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": err.Error(),
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	err = rt.db.SetPostPublication(postid, status, at)
+	if err != nil {
+		context.Logger.Error("Error updating post publication\nDetail: ", err.Error())
+		http.Error(w, "Something wrong updating your post", http.StatusInternalServerError)
+		return
+	}
+
+	// Publication correctly updated, return the post
+	postDB, err = rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving updated post in setting publication request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong updating your post", http.StatusInternalServerError)
+		return
+	}
+
+	var post Post
+	err = post.FromDatabase(postDB)
+	if err != nil {
+		context.Logger.Error("Error converting PostDB to PostAPI in setting publication request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong updating your post", http.StatusInternalServerError)
+		return
+	}
+
+	// Change datetime format for each comment
+	for i := 0; i < len(post.Comments); i++ {
+		post.Comments[i].Datetime, _ = formatDatetime(post.Comments[i].Datetime)
+	}
+	post.Datetime, _ = formatDatetime(post.Datetime)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Post{"post": post})
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// setReaction allows a user to react to a post, or to one of its comments if the comment id is in the path. The body
+// contains the reaction type, one of the ReactionTypes. If the user has already reacted, the reaction is replaced.
+// If the user is not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the post id (or the comment id) doesn't exist, the request will fail.
+// If the post owner has banned the user, the request will fail.
+// If the user cannot see the post (see canSeePost), the request will fail.
+// If the reaction type is not valid, the request will fail.
+// If the request is OK, it will return the Reaction{} object.
+func (rt *_router) setReaction(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in setting reaction request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in setting reaction request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes setting reaction request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for setting reaction request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in setting reaction request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Post ID in the path is a 64-bit unsigned integer. Let's parse it.
+	postid, err := strconv.ParseUint(params.ByName("postid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing postid in setting reaction request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for postid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the post exists
+	check, err = rt.db.CheckPostByPostid(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on postid for setting reaction!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in setting reaction request! Post doesn't exist")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// The Comment ID is in the path only for the reactions to a comment (0 means the post itself)
+	var commentid uint64
+	if params.ByName("commentid") != "" {
+		commentid, err = strconv.ParseUint(params.ByName("commentid"), 10, 64)
+
+		if err != nil {
+			context.Logger.Error("Error parsing commentid in setting reaction request")
+			w.WriteHeader(http.StatusBadRequest)
+
+			response := map[string]string{
+				"error": "not correct format for commentid",
+			}
+
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		// check if the comment is under the post
+		check, err = rt.db.CheckCommentOnPost(commentid, postid)
+		if err != nil {
+			context.Logger.Error("Error retrieving information on commentid for setting reaction!\nDetail: ", err.Error())
+			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+			return
+		}
+
+		if !check {
+			context.Logger.Error("Error in setting reaction request! Comment doesn't exist")
+			http.Error(w, "Comment seems not exist.", http.StatusNotFound)
+			return
+		}
+	}
+
+	// Check if post owner has not banned the user
+	postDB, err := rt.db.GetPost(postid)
+	if err != nil {
+		context.Logger.Error("Error retrieving post information in setting reaction request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	banned, err := rt.db.HasBanned(postDB.Uid, uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving ban information in setting reaction request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if banned {
+		context.Logger.Error("User is banned by post owner in setting reaction request!")
+		http.Error(w, "You cannot react", http.StatusForbidden)
+		return
+	}
+
+	// check if the post is visible to the user
+	visible, err := rt.canSeePost(postDB, uid, context.Role)
+	if err != nil {
+		context.Logger.Error("Error checking post visibility in setting reaction request!\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !visible {
+		context.Logger.Error("Post is not visible to the user in setting reaction request!")
+		http.Error(w, "Post seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	var reaction Reaction
+	err = json.NewDecoder(r.Body).Decode(&reaction)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !reaction.IsValid() {
+		context.Logger.Error("Reaction type is not valid!")
+		http.Error(w, "Reaction type is not valid", http.StatusBadRequest)
+		return
+	}
+
+	err = rt.db.SetReaction(postid, commentid, uid, reaction.Type)
+	if err != nil {
+		context.Logger.Error("Error adding reaction to table.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong adding the reaction.", http.StatusInternalServerError)
+		return
+	}
+
+	// Reaction correctly added
+	// Now return Reaction{} struct
+	userdb, err := rt.db.GetUserByID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving user structure in setting reaction request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong adding the reaction.", http.StatusInternalServerError)
+		return
+	}
+
+	_ = reaction.User.FromDatabase(userdb)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Reaction{"reaction": reaction})
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// setUserRole allows an administrator to change the role of a user, passing one of the Roles in the "role" field of
+// the body. The change is written in the audit trail.
+// If the user is not authorized or he is not an administrator, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the role is not valid, or the administrator changes his own role, the request will fail.
+// If the request is OK, it will return the Account{} object of the user.
+func (rt *_router) setUserRole(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in setting role request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in setting role request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is an administrator
+	if context.Role != database.RoleAdmin {
+		context.Logger.Error("The user that makes setting role request is not an administrator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for setting role request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in setting role request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	var account Account
+	err = json.NewDecoder(r.Body).Decode(&account)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !account.IsValid() {
+		context.Logger.Error("Role is not valid!")
+		http.Error(w, "Role is not valid", http.StatusBadRequest)
+		return
+	}
+
+	// an administrator cannot lose his own role, so there is always an administrator
+	if uid == context.Uid {
+		context.Logger.Error("Error in setting role request! Administrator is changing his own role")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "you cannot change your own role",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	err = rt.db.SetUserRole(uid, account.Role)
+	if err != nil {
+		context.Logger.Error("Error setting role.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong setting the role.", http.StatusInternalServerError)
+		return
+	}
+
+	err = rt.db.AddModerationAction(database.ModerationAction{
+		Actor:  context.Uid,
+		Action: ModerationSetRole,
+		Uid:    uid,
+		Detail: account.Role,
+	})
+	if err != nil {
+		context.Logger.Error("Error writing audit trail in setting role request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong setting the role.", http.StatusInternalServerError)
+		return
+	}
+
+	account, err = rt.getAccount(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving account in setting role request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Account{"account": account})
+}
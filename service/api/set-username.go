@@ -3,6 +3,8 @@ package api
 import (
 	"encoding/json"
 	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
 	"github.com/julienschmidt/httprouter"
 	"net/http"
 	"strconv"
@@ -10,7 +12,8 @@ import (
 
 // setUsername allows setting a new username for a user from his uid
 // The function will return the new User{} object if the username is not already set and
-// the user has the right Auth Token
+// the user has the right Auth Token. The old username is kept in the username history: it is reserved for a while
+// and getUserByName resolves it to the user. The username can't be changed too often
 func (rt *_router) setUsername(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
 	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
 	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
@@ -56,10 +59,13 @@ func (rt *_router) setUsername(w http.ResponseWriter, r *http.Request, params ht
 		// The body was not a parseable JSON, reject it
 		w.WriteHeader(http.StatusBadRequest)
 		return
-	} else if !user.IsValid() {
+	}
+
+	user.Username = normalizeText(user.Username)
+	if !user.IsValid() {
 		// Here we validated the user structure content (e.g., username has correct format)
 		// Note: the IsValid() function skips the ID check.
-		context.Logger.Error("Error, User structure is not valid!\nDetail: ", err.Error())
+		context.Logger.Error("Error, User structure is not valid!")
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -67,8 +73,52 @@ func (rt *_router) setUsername(w http.ResponseWriter, r *http.Request, params ht
 	user.Userid = uid
 
 	userdb := user.ToDatabase()
+
+	// the new username cannot be confusable with the username of another user
+	skeleton := database.UsernameSkeleton(userdb.Username)
+	confusable, err := rt.db.CheckExistsBySkeleton(skeleton, userdb.Userid)
+	if err != nil {
+		context.Logger.Error("Error checking confusable usernames\nDetail: ", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if confusable {
+		context.Logger.Error("Error setting the new username, confusable with an existing one")
+		http.Error(w, "Username is too similar to an existing one", http.StatusConflict)
+		return
+	}
+
+	// a username left by another user is reserved for a while, so that his old links don't lead to someone else
+	reserved, err := rt.usernameReserved(userdb.Username, skeleton, userdb.Userid)
+	if err != nil {
+		context.Logger.Error("Error checking released usernames\nDetail: ", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if reserved {
+		context.Logger.Error("Error setting the new username, recently released by another user")
+		http.Error(w, "Username has been recently released by another user", http.StatusConflict)
+		return
+	}
+
+	// the username can't be changed too often
+	renamed, err := rt.renamedRecently(userdb.Userid)
+	if err != nil {
+		context.Logger.Error("Error checking last username change\nDetail: ", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if renamed {
+		context.Logger.Error("Error setting the new username, username changed too recently")
+		http.Error(w, "Username changed too recently, try again later", http.StatusTooManyRequests)
+		return
+	}
+
 	// try change user username, if username is already taken the request will fail
-	err = rt.db.SetUsername(userdb.Userid, user.Username)
+	err = rt.db.SetUsername(userdb.Userid, user.Username, skeleton, globaltime.Now())
 	if err != nil {
 		context.Logger.Error("Error setting the new username, already taken")
 		http.Error(w, "Username already taken. Username must be unique", http.StatusConflict)
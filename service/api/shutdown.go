@@ -2,5 +2,9 @@ package api
 
 // Close should close everything opened in the lifecycle of the `_router`; for example, background goroutines.
 func (rt *_router) Close() error {
+	rt.stopStoryReaper()
+	rt.stopDeletionWorker()
+	rt.stopExportWorker()
+	rt.stopPostScheduler()
 	return nil
 }
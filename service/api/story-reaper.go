@@ -0,0 +1,87 @@
+package api
+
+import (
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// StoryDuration is how long a story is visible after its upload
+	StoryDuration = 24 * time.Hour
+	// StoryReaperInterval is how often the expired stories are removed
+	StoryReaperInterval = time.Minute
+	// StoryImagesDirectory is where the story images are stored
+	StoryImagesDirectory = "media/stories"
+)
+
+// startStoryReaper starts the background goroutine that removes the expired stories and their images every
+// StoryReaperInterval. The goroutine is stopped by Close.
+func (rt *_router) startStoryReaper() {
+	rt.reaperStop = make(chan struct{})
+	rt.reaperDone = make(chan struct{})
+
+	go func() {
+		defer close(rt.reaperDone)
+
+		ticker := time.NewTicker(StoryReaperInterval)
+		defer ticker.Stop()
+
+		for {
+			rt.reapStories()
+
+			select {
+			case <-rt.reaperStop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stopStoryReaper stops the goroutine started by startStoryReaper and waits for it to exit.
+func (rt *_router) stopStoryReaper() {
+	close(rt.reaperStop)
+	<-rt.reaperDone
+}
+
+// reapStories removes the stories expired at globaltime.Now(). The image is removed before the story, so that a story
+// whose image cannot be removed is tried again at the next run. Errors are logged.
+func (rt *_router) reapStories() {
+	stories, err := rt.db.GetExpiredStories(globaltime.Now())
+	if err != nil {
+		rt.baseLogger.WithError(err).Error("error retrieving expired stories")
+		return
+	}
+
+	for _, story := range stories {
+		err = deleteStoryImage(story)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error removing image of story %d", story.Storyid)
+			continue
+		}
+
+		err = rt.db.RemoveStory(story.Storyid)
+		if err != nil {
+			rt.baseLogger.WithError(err).Errorf("error removing story %d", story.Storyid)
+		}
+	}
+}
+
+// storyImagePath returns the path of the image file of a story
+func storyImagePath(story database.Story) string {
+	return filepath.Join(StoryImagesDirectory, strconv.FormatUint(story.Storyid, 10)+"."+story.Format)
+}
+
+// deleteStoryImage allows to remove the image file of a story.
+// Function will return nil if the file is correctly removed (or it's already missing), an error otherwise
+func deleteStoryImage(story database.Story) error {
+	err := os.Remove(storyImagePath(story))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
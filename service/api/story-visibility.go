@@ -0,0 +1,27 @@
+package api
+
+import "github.com/Simone0401/WASAPhoto/service/database"
+
+// canSeeStory checks if uid can see a story: the owner always can, the other users only if they follow the owner and
+// there is no ban between them.
+func (rt *_router) canSeeStory(story database.Story, uid uint64) (bool, error) {
+	if story.Uid == uid {
+		return true, nil
+	}
+
+	followed, err := rt.db.HasFollowed(uid, story.Uid)
+	if err != nil || !followed {
+		return false, err
+	}
+
+	banned, err := rt.db.HasBanned(story.Uid, uid)
+	if err != nil || banned {
+		return false, err
+	}
+
+	muted, err := rt.db.HasBanned(uid, story.Uid)
+	if err != nil {
+		return false, err
+	}
+	return !muted, nil
+}
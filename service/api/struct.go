@@ -1,15 +1,43 @@
 package api
 
 import (
+	"fmt"
 	"github.com/Simone0401/WASAPhoto/service/database"
+	"html"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 const (
-	UserUsernameRegex   string = "^[A-Za-z0-9]{3,20}$"
-	MessageCommentRegex string = "^[a-zA-Z0-9.,!?;:'\"\\s]+$"
+	// UserUsernameRegex allows letters (of any script), combining marks and digits
+	UserUsernameRegex string = "^[\\p{L}\\p{M}\\p{N}]+$"
+	// MessageCommentRegex allows any printable character, emoji sequences included (zero width joiner and tags)
+	MessageCommentRegex string = "^[\\p{L}\\p{M}\\p{N}\\p{P}\\p{S}\\p{Zs}\\s\\x{200D}\\x{E0020}-\\x{E007F}]+$"
+
+	// Username and comment lengths are expressed in user-perceived characters (grapheme clusters).
+	UsernameMinLength int = 3
+	UsernameMaxLength int = 20
+	MessageMaxLength  int = 256
+
+	// UsernameMaxRunes and MessageMaxRunes are the maximum number of code points that the database can store.
+	UsernameMaxRunes int = 80
+	MessageMaxRunes  int = 1024
+
+	// CaptionMaxLength is the maximum length of a post caption in user-perceived characters
+	CaptionMaxLength int = 512
+	// CaptionMaxRunes is the maximum number of code points of a post caption
+	CaptionMaxRunes int = 2048
+
+	// CollectionNameMaxLength is the maximum length of a collection name in user-perceived characters
+	CollectionNameMaxLength int = 32
+	// CollectionNameMaxRunes is the maximum number of code points of a collection name
+	CollectionNameMaxRunes int = 128
 )
 
+// ReactionTypes are the reactions that a user can give to a post or to a comment. The like is the "heart" reaction.
+var ReactionTypes = []string{database.ReactionHeart, "laugh", "wow", "sad", "angry", "clap"}
+
 // User struct represents a user in every data exchange with the external world via REST API. JSON tags have been
 // added to the struct to conform to the OpenAPI specifications regarding JSON key names.
 // Note: there is a similar struct in the database package.
@@ -19,25 +47,266 @@ type User struct {
 }
 
 // Comment struct represents a comment in every data exchange with the external world via REST API. JSON tags have been
-// added to the struct to conform to the OpenAPI specifications regarding JSON key names.
+// added to the struct to conform to the OpenAPI specifications regarding JSON key names. LikedByMe reports if the user
+// that made the request has liked the comment (see markLikedComments).
 // Note: there is a similar struct in the database package.
 type Comment struct {
-	Commentid uint64 `json:"id"`
-	Userid    uint64 `json:"uid"`
-	Postid    uint64 `json:"postid"`
-	Message   string `json:"message" validate:"min=1, max=256"`
-	Datetime  string `json:"comment_datetime"`
+	Commentid uint64            `json:"id"`
+	Userid    uint64            `json:"uid"`
+	Postid    uint64            `json:"postid"`
+	Message   string            `json:"message" validate:"min=1, max=256"`
+	Datetime  string            `json:"comment_datetime"`
+	Mentions  []Mention         `json:"mentions" validate:"dive"`
+	Reactions map[string]uint64 `json:"reactions"`
+	Likes     uint64            `json:"likes" validate:"min=0"`
+	LikedByMe bool              `json:"liked_by_me"`
+}
+
+// Mention struct represents a @username mention inside a comment in every data exchange with the external world via
+// REST API. Offset and Length are expressed in characters (code points) of the comment message, and Username is the
+// current username of the mentioned user (it can differ from the one written in the message).
+// Note: there is a similar struct in the database package.
+type Mention struct {
+	Uid      uint64 `json:"uid"`
+	Username string `json:"username"`
+	Offset   uint64 `json:"offset" validate:"min=0"`
+	Length   uint64 `json:"length" validate:"min=2"`
+}
+
+// Notification struct represents a notification in every data exchange with the external world via REST API. JSON
+// tags have been added to the struct to conform to the OpenAPI specifications regarding JSON key names.
+// Note: there is a similar struct in the database package.
+type Notification struct {
+	Notificationid uint64 `json:"id"`
+	Uid            uint64 `json:"uid"`
+	Type           string `json:"type"`
+	Actor          uint64 `json:"actor"`
+	Postid         uint64 `json:"postid"`
+	Commentid      uint64 `json:"commentid"`
+	Datetime       string `json:"notification_datetime"`
 }
 
 // Post struct represents a post structure in every data exchange with the external world via REST API. JSON tags have been
 // added to the struct to conform to the OpenAPI specifications regarding JSON key names.
 // Note: there is a similar struct in the database package.
 type Post struct {
-	Postid   uint64    `json:"postid"`
-	Uid      uint64    `json:"uid"`
-	Likes    uint64    `json:"likes" validate:"min=0"`
-	Comments []Comment `json:"comments" validate:"dive"` // Validate Comments slice element, too
-	Datetime string    `json:"upload_datetime" validate:"datetimeformat"`
+	Postid    uint64            `json:"postid"`
+	Uid       uint64            `json:"uid"`
+	Likes     uint64            `json:"likes" validate:"min=0"`
+	Comments  []Comment         `json:"comments" validate:"dive"` // Validate Comments slice element, too
+	Datetime  string            `json:"upload_datetime" validate:"datetimeformat"`
+	Caption   string            `json:"caption" validate:"max=512"`
+	Media     []Media           `json:"media" validate:"min=1, max=10, dive"`
+	Reactions map[string]uint64 `json:"reactions"`
+	Repost    *Repost           `json:"repost,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	PublishAt string            `json:"publish_datetime,omitempty"`
+	Archived  bool              `json:"archived,omitempty"`
+}
+
+// Publication struct represents the requested status of an unpublished post: "draft", "scheduled" (to be published at
+// PublishAt) or "published".
+type Publication struct {
+	Status    string `json:"status"`
+	PublishAt string `json:"publish_datetime"`
+}
+
+// Repost struct represents the repost of a post by a user, with an optional quote, in every data exchange with the
+// external world via REST API. Summary is a human readable description like "alice reposted bob's photo".
+// Note: there is a similar struct in the database package.
+type Repost struct {
+	Repostid uint64 `json:"id"`
+	User     User   `json:"user" validate:"dive"`
+	Postid   uint64 `json:"postid"`
+	Quote    string `json:"quote" validate:"max=256"`
+	Datetime string `json:"repost_datetime" validate:"datetimeformat"`
+	Summary  string `json:"summary"`
+}
+
+// Media struct represents an image of a post carousel in every data exchange with the external world via REST API.
+// The binary image is returned by the 'Get Image API' passing the media ID. Width and Height are in pixels.
+// Note: there is a similar struct in the database package.
+type Media struct {
+	Mediaid uint64 `json:"id"`
+	Width   uint64 `json:"width" validate:"min=0"`
+	Height  uint64 `json:"height" validate:"min=0"`
+}
+
+// PostMatch struct represents a post found by the post search in every data exchange with the external world via REST
+// API. Commentid is the comment that matches (0 if the caption matches); Snippet is the matching part of its text,
+// HTML escaped, with the matched words between <mark> and </mark>.
+// Note: there is a similar struct in the database package.
+type PostMatch struct {
+	Post      Post   `json:"post" validate:"dive"`
+	Commentid uint64 `json:"commentid"`
+	Snippet   string `json:"snippet"`
+}
+
+// Tag struct represents a hashtag and the number of posts that use it in every data exchange with the external world
+// via REST API. JSON tags have been added to the struct to conform to the OpenAPI specifications regarding JSON key
+// names.
+// Note: there is a similar struct in the database package.
+type Tag struct {
+	Name  string `json:"tag"`
+	Posts uint64 `json:"posts" validate:"min=0"`
+}
+
+// Suggestion struct represents a user that could be followed in every data exchange with the external world via REST
+// API. Reason explains why the user is suggested (e.g., "followed by alice and 3 others").
+// Note: there is a similar struct in the database package.
+type Suggestion struct {
+	User    User   `json:"user" validate:"dive"`
+	Mutuals uint64 `json:"mutuals" validate:"min=1"`
+	Reason  string `json:"reason"`
+}
+
+// Collection struct represents a named collection of saved posts in every data exchange with the external world via
+// REST API. JSON tags have been added to the struct to conform to the OpenAPI specifications regarding JSON key names.
+// Note: there is a similar struct in the database package.
+type Collection struct {
+	Collectionid uint64 `json:"id"`
+	Uid          uint64 `json:"uid"`
+	Name         string `json:"name" validate:"min=1, max=32"`
+	Posts        uint64 `json:"posts" validate:"min=0"`
+	Datetime     string `json:"creation_datetime"`
+}
+
+// Reaction struct represents the reaction of a user to a post or to a comment in every data exchange with the
+// external world via REST API. Type is one of the ReactionTypes.
+type Reaction struct {
+	User User   `json:"user" validate:"dive"`
+	Type string `json:"type"`
+}
+
+// Report struct represents the report of a post (Commentid is 0) or of a comment in every data exchange with the
+// external world via REST API. Reason is one of the ReportReasons, and Reports is the number of open reports of the
+// same content (it's returned only to the moderators).
+// Note: there is a similar struct in the database package.
+type Report struct {
+	Reportid  uint64 `json:"id"`
+	Reporter  User   `json:"reporter" validate:"dive"`
+	Postid    uint64 `json:"postid"`
+	Commentid uint64 `json:"commentid"`
+	Reason    string `json:"reason"`
+	Status    string `json:"status"`
+	Datetime  string `json:"report_datetime" validate:"datetimeformat"`
+	Reports   uint64 `json:"reports,omitempty"`
+}
+
+// ModerationAction struct represents an entry of the moderation audit trail in every data exchange with the external
+// world via REST API. Actor is 0 for the automatic actions; Uid is the user target of the action (0 when not relevant).
+// Note: there is a similar struct in the database package.
+type ModerationAction struct {
+	Actionid  uint64 `json:"id"`
+	Actor     uint64 `json:"actor"`
+	Action    string `json:"action"`
+	Uid       uint64 `json:"uid"`
+	Reportid  uint64 `json:"reportid"`
+	Postid    uint64 `json:"postid"`
+	Commentid uint64 `json:"commentid"`
+	Detail    string `json:"detail"`
+	Datetime  string `json:"action_datetime" validate:"datetimeformat"`
+}
+
+// Account struct represents the role and the status of a user in every data exchange with the external world via REST
+// API. Reason and Until are set only for the suspended users (Until is empty if the suspension has no end).
+type Account struct {
+	User   User   `json:"user" validate:"dive"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+	Reason string `json:"status_reason,omitempty"`
+	Until  string `json:"status_until,omitempty"`
+}
+
+// Stats struct represents the system statistics in every data exchange with the external world via REST API.
+// Note: there is a similar struct in the database package.
+type Stats struct {
+	Users          uint64 `json:"users"`
+	SuspendedUsers uint64 `json:"suspended_users"`
+	Posts          uint64 `json:"posts"`
+	HiddenPosts    uint64 `json:"hidden_posts"`
+	Comments       uint64 `json:"comments"`
+	HiddenComments uint64 `json:"hidden_comments"`
+	Reactions      uint64 `json:"reactions"`
+	ActiveStories  uint64 `json:"active_stories"`
+	OpenReports    uint64 `json:"open_reports"`
+}
+
+// Export struct represents an archive with the personal data of a user in every data exchange with the external world
+// via REST API. Link is the download link of the archive, valid until Expiration; both are set only when the archive
+// is ready.
+// Note: there is a similar struct in the database package.
+type Export struct {
+	Status     string `json:"status"`
+	Datetime   string `json:"request_datetime"`
+	Expiration string `json:"expiration_datetime,omitempty"`
+	Link       string `json:"link,omitempty"`
+}
+
+// ExportedPost struct represents a post in the archive with the personal data of a user. Images are the paths of the
+// images of the carousel inside the archive, in order. Status and PublishAt are set only for the unpublished posts, as
+// in Post.
+type ExportedPost struct {
+	Postid    uint64   `json:"postid"`
+	Datetime  string   `json:"upload_datetime"`
+	Caption   string   `json:"caption"`
+	Images    []string `json:"images"`
+	Status    string   `json:"status,omitempty"`
+	PublishAt string   `json:"publish_datetime,omitempty"`
+}
+
+// ExportedReaction struct represents a reaction of a user to a post (Commentid is 0) or to a comment in the archive
+// with the personal data of a user. Datetime is empty for the old likes.
+type ExportedReaction struct {
+	Postid    uint64 `json:"postid"`
+	Commentid uint64 `json:"commentid"`
+	Type      string `json:"type"`
+	Datetime  string `json:"reaction_datetime,omitempty"`
+}
+
+// ImportResult struct represents the outcome of the import of an archive: the number of posts created, the number of
+// posts skipped because already imported, and the posts rejected with the reason.
+type ImportResult struct {
+	Imported uint64            `json:"imported"`
+	Skipped  uint64            `json:"skipped"`
+	Rejected []ImportRejection `json:"rejected"`
+}
+
+// ImportRejection struct represents a post of an archive that cannot be imported. Postid is the id in the archive.
+type ImportRejection struct {
+	Postid uint64 `json:"postid"`
+	Error  string `json:"error"`
+}
+
+// Story struct represents a story in every data exchange with the external world via REST API. The binary image is
+// returned by the 'Get Story Image API'. Seen reports if the user has already seen the story, while Views is the
+// number of users that have seen it and it's returned only to the story owner.
+// Note: there is a similar struct in the database package.
+type Story struct {
+	Storyid    uint64 `json:"id"`
+	Uid        uint64 `json:"uid"`
+	Width      uint64 `json:"width" validate:"min=0"`
+	Height     uint64 `json:"height" validate:"min=0"`
+	Datetime   string `json:"upload_datetime"`
+	Expiration string `json:"expiration_datetime"`
+	Seen       bool   `json:"seen"`
+	Views      uint64 `json:"views,omitempty"`
+}
+
+// StoryGroup struct represents the stories of a followed user in the stream of stories, in upload order. Seen is true
+// if all the stories of the group have been seen.
+type StoryGroup struct {
+	User    User    `json:"user" validate:"dive"`
+	Stories []Story `json:"stories" validate:"dive"`
+	Seen    bool    `json:"seen"`
+}
+
+// StoryView struct represents a user that has seen a story in every data exchange with the external world via REST
+// API.
+// Note: there is a similar struct in the database package.
+type StoryView struct {
+	User     User   `json:"user" validate:"dive"`
+	Datetime string `json:"view_datetime"`
 }
 
 // ProfileInfo struct represents a Profile structure in every data exchange with the external world via REST API. JSON tags have been
@@ -60,10 +329,19 @@ type ProfileInfo struct {
 // the database should somehow be JSON-serializable (or, in general, serializable).
 func (u *User) FromDatabase(user database.User) error {
 	u.Userid = user.Userid
-	u.Username = user.Username
+	u.Username = displayUsername(user.Username)
 	return nil
 }
 
+// displayUsername returns the username to show for a user: the tombstones of the deleted users are shown as
+// database.DeletedUsername.
+func displayUsername(username string) string {
+	if strings.HasPrefix(username, database.DeletedUsername+" ") {
+		return database.DeletedUsername
+	}
+	return username
+}
+
 // ToDatabase returns the user in a database-compatible representation
 func (u *User) ToDatabase() database.User {
 	return database.User{
@@ -79,17 +357,32 @@ func (c *Comment) FromDatabase(comment database.Comment) error {
 	c.Postid = comment.Postid
 	c.Message = comment.Message
 	c.Datetime = comment.Datetime
+	c.Mentions = nil
+	for _, mention := range comment.Mentions {
+		mention.Username = displayUsername(mention.Username)
+		c.Mentions = append(c.Mentions, Mention(mention))
+	}
+	c.Reactions = reactionCounts(comment.Reactions)
+	c.Likes = comment.Likes
+	c.LikedByMe = false
 	return nil
 }
 
 // ToDatabase returns comment in a database-compatible representation
 func (c *Comment) ToDatabase() database.Comment {
+	var mentions []database.Mention
+	for _, mention := range c.Mentions {
+		mentions = append(mentions, database.Mention(mention))
+	}
 	return database.Comment{
 		Commentid: c.Commentid,
 		Userid:    c.Userid,
 		Postid:    c.Postid,
 		Message:   c.Message,
 		Datetime:  c.Datetime,
+		Mentions:  mentions,
+		Reactions: c.Reactions,
+		Likes:     c.Likes,
 	}
 }
 
@@ -99,9 +392,33 @@ func (p *Post) FromDatabase(post database.Post) error {
 	p.Uid = post.Uid
 	p.Likes = post.Likes
 	for _, comment := range post.Comments {
-		p.Comments = append(p.Comments, Comment(comment))
+		var commentAPI Comment
+		_ = commentAPI.FromDatabase(comment)
+		p.Comments = append(p.Comments, commentAPI)
 	}
 	p.Datetime = post.Datetime
+	p.Caption = post.Caption
+	p.Media = nil
+	for _, media := range post.Media {
+		var mediaAPI Media
+		_ = mediaAPI.FromDatabase(media)
+		p.Media = append(p.Media, mediaAPI)
+	}
+	p.Reactions = reactionCounts(post.Reactions)
+	p.Repost = nil
+	if post.Repost.Repostid != 0 {
+		var repostAPI Repost
+		_ = repostAPI.FromDatabase(post.Repost)
+		p.Repost = &repostAPI
+	}
+	p.Archived = post.Archived
+	// the status is shown only for the unpublished posts
+	p.Status = ""
+	p.PublishAt = ""
+	if post.Status != "" && post.Status != database.PostPublished {
+		p.Status = post.Status
+		p.PublishAt, _ = formatDatetime(post.PublishAt)
+	}
 	return nil
 }
 
@@ -112,12 +429,97 @@ func (p *Post) ToDatabase() database.Post {
 	postDatabase.Uid = p.Uid
 	postDatabase.Likes = p.Likes
 	for _, comment := range p.Comments {
-		postDatabase.Comments = append(postDatabase.Comments, database.Comment(comment))
+		postDatabase.Comments = append(postDatabase.Comments, comment.ToDatabase())
 	}
 	postDatabase.Datetime = p.Datetime
+	postDatabase.Caption = p.Caption
+	for _, media := range p.Media {
+		mediaDatabase := media.ToDatabase()
+		mediaDatabase.Postid = p.Postid
+		postDatabase.Media = append(postDatabase.Media, mediaDatabase)
+	}
+	postDatabase.Reactions = p.Reactions
 	return postDatabase
 }
 
+// reactionCounts returns the number of reactions of each of the ReactionTypes, 0 for the types without reactions.
+func reactionCounts(reactions map[string]uint64) map[string]uint64 {
+	counts := make(map[string]uint64, len(ReactionTypes))
+	for _, reaction := range ReactionTypes {
+		counts[reaction] = reactions[reaction]
+	}
+	return counts
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values. The summary is built from
+// the usernames of the reposting user and of the original post owner.
+func (r *Repost) FromDatabase(repost database.Repost) error {
+	r.Repostid = repost.Repostid
+	_ = r.User.FromDatabase(repost.User)
+	r.Postid = repost.Postid
+	r.Quote = repost.Quote
+	r.Datetime = repost.Datetime
+	r.Summary = fmt.Sprintf("%s reposted %s's photo", repost.User.Username, repost.Owner)
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (m *Media) FromDatabase(media database.Media) error {
+	m.Mediaid = media.Mediaid
+	m.Width = media.Width
+	m.Height = media.Height
+	return nil
+}
+
+// ToDatabase returns media in a database-compatible representation. Postid and Format are not known by the API.
+func (m *Media) ToDatabase() database.Media {
+	return database.Media{
+		Mediaid: m.Mediaid,
+		Width:   m.Width,
+		Height:  m.Height,
+	}
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (n *Notification) FromDatabase(notification database.Notification) error {
+	n.Notificationid = notification.Notificationid
+	n.Uid = notification.Uid
+	n.Type = notification.Type
+	n.Actor = notification.Actor
+	n.Postid = notification.Postid
+	n.Commentid = notification.Commentid
+	n.Datetime = notification.Datetime
+	return nil
+}
+
+// ToDatabase returns notification in a database-compatible representation
+func (n *Notification) ToDatabase() database.Notification {
+	return database.Notification{
+		Notificationid: n.Notificationid,
+		Uid:            n.Uid,
+		Type:           n.Type,
+		Actor:          n.Actor,
+		Postid:         n.Postid,
+		Commentid:      n.Commentid,
+		Datetime:       n.Datetime,
+	}
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (t *Tag) FromDatabase(tag database.Tag) error {
+	t.Name = tag.Name
+	t.Posts = tag.Posts
+	return nil
+}
+
+// ToDatabase returns tag in a database-compatible representation
+func (t *Tag) ToDatabase() database.Tag {
+	return database.Tag{
+		Name:  t.Name,
+		Posts: t.Posts,
+	}
+}
+
 // FromDatabase populates the struct with data from the database, overwriting all values.
 func (p *ProfileInfo) FromDatabase(profile database.Profile) error {
 	err := p.User.FromDatabase(profile.User)
@@ -140,16 +542,226 @@ func (p *ProfileInfo) ToDatabase() database.Profile {
 	return profileDatabase
 }
 
-// IsValid checks the validity of the content. In particular, username should be in its range of validity.
+// FromDatabase populates the struct with data from the database, overwriting all values.
+// The snippet is HTML escaped, so that it can be shown with the matched words highlighted.
+func (m *PostMatch) FromDatabase(match database.PostMatch) error {
+	err := m.Post.FromDatabase(match.Post)
+	if err != nil {
+		return err
+	}
+	m.Commentid = match.Commentid
+	m.Snippet = strings.NewReplacer(database.SnippetStart, "<mark>", database.SnippetEnd, "</mark>").
+		Replace(html.EscapeString(match.Snippet))
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (c *Collection) FromDatabase(collection database.Collection) error {
+	c.Collectionid = collection.Collectionid
+	c.Uid = collection.Uid
+	c.Name = collection.Name
+	c.Posts = collection.Posts
+	c.Datetime = collection.Datetime
+	return nil
+}
+
+// ToDatabase returns collection in a database-compatible representation
+func (c *Collection) ToDatabase() database.Collection {
+	return database.Collection{
+		Collectionid: c.Collectionid,
+		Uid:          c.Uid,
+		Name:         c.Name,
+		Posts:        c.Posts,
+		Datetime:     c.Datetime,
+	}
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (s *Story) FromDatabase(story database.Story) error {
+	s.Storyid = story.Storyid
+	s.Uid = story.Uid
+	s.Width = story.Width
+	s.Height = story.Height
+	s.Datetime = story.Datetime
+	s.Expiration = story.Expiration
+	s.Seen = story.Seen
+	s.Views = story.Views
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (v *StoryView) FromDatabase(view database.StoryView) error {
+	err := v.User.FromDatabase(view.User)
+	if err != nil {
+		return err
+	}
+	v.Datetime = view.Datetime
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (r *Report) FromDatabase(report database.Report) error {
+	r.Reportid = report.Reportid
+	_ = r.Reporter.FromDatabase(report.Reporter)
+	r.Postid = report.Postid
+	r.Commentid = report.Commentid
+	r.Reason = report.Reason
+	r.Status = report.Status
+	r.Datetime = report.Datetime
+	r.Reports = report.Reports
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (a *ModerationAction) FromDatabase(action database.ModerationAction) error {
+	a.Actionid = action.Actionid
+	a.Actor = action.Actor
+	a.Action = action.Action
+	a.Uid = action.Uid
+	a.Reportid = action.Reportid
+	a.Postid = action.Postid
+	a.Commentid = action.Commentid
+	a.Detail = action.Detail
+	a.Datetime = action.Datetime
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (s *Stats) FromDatabase(stats database.Stats) error {
+	s.Users = stats.Users
+	s.SuspendedUsers = stats.SuspendedUsers
+	s.Posts = stats.Posts
+	s.HiddenPosts = stats.HiddenPosts
+	s.Comments = stats.Comments
+	s.HiddenComments = stats.HiddenComments
+	s.Reactions = stats.Reactions
+	s.ActiveStories = stats.ActiveStories
+	s.OpenReports = stats.OpenReports
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values. Dates are formatted.
+func (e *Export) FromDatabase(export database.Export) error {
+	e.Status = export.Status
+	e.Datetime, _ = formatDatetime(export.Datetime)
+	e.Expiration = ""
+	e.Link = ""
+	if export.Status == database.ExportReady {
+		e.Expiration, _ = formatDatetime(export.Expiration)
+		e.Link = "/exports/" + export.Token
+	}
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (r *ExportedReaction) FromDatabase(reaction database.UserReaction) error {
+	r.Postid = reaction.Postid
+	r.Commentid = reaction.Commentid
+	r.Type = reaction.Type
+	r.Datetime, _ = formatDatetime(reaction.Datetime)
+	return nil
+}
+
+// FromDatabase populates the struct with data from the database, overwriting all values.
+func (s *Suggestion) FromDatabase(suggestion database.Suggestion) error {
+	err := s.User.FromDatabase(suggestion.User)
+	if err != nil {
+		return err
+	}
+	s.Mutuals = suggestion.Mutuals
+	switch {
+	case suggestion.Mutuals <= 1:
+		s.Reason = fmt.Sprintf("followed by %s", suggestion.Via)
+	case suggestion.Mutuals == 2:
+		s.Reason = fmt.Sprintf("followed by %s and 1 other", suggestion.Via)
+	default:
+		s.Reason = fmt.Sprintf("followed by %s and %d others", suggestion.Via, suggestion.Mutuals-1)
+	}
+	return nil
+}
+
+// IsValid checks the validity of the content. In particular, username should be in its range of validity and
+// it cannot mix characters from different scripts. The username is expected to be already NFC normalized.
 // Note that the ID is not checked.
 func (u *User) IsValid() bool {
 	regexPattern := regexp.MustCompile(UserUsernameRegex)
-	return regexPattern.MatchString(u.Username) && len(u.Username) > 2 && len(u.Username) < 21
+	length := graphemeCount(u.Username)
+	return regexPattern.MatchString(u.Username) && length >= UsernameMinLength && length <= UsernameMaxLength &&
+		utf8.RuneCountInString(u.Username) <= UsernameMaxRunes && isSingleScript(u.Username)
 }
 
 // IsValid checks the validity of the content. In particular, comment message should be in it range of validity.
+// The message is expected to be already NFC normalized.
 // Note that ID is not checked.
 func (c *Comment) IsValid() bool {
 	regexPattern := regexp.MustCompile(MessageCommentRegex)
-	return regexPattern.MatchString(c.Message) && len(c.Message) > 0 && len(c.Message) < 257
+	length := graphemeCount(c.Message)
+	return regexPattern.MatchString(c.Message) && strings.TrimSpace(c.Message) != "" && length <= MessageMaxLength &&
+		utf8.RuneCountInString(c.Message) <= MessageMaxRunes
+}
+
+// IsValid checks the validity of the content. In particular, caption should be in its range of validity (it can be
+// empty). The caption is expected to be already NFC normalized.
+// Note that IDs are not checked.
+func (p *Post) IsValid() bool {
+	if p.Caption == "" {
+		return true
+	}
+	regexPattern := regexp.MustCompile(MessageCommentRegex)
+	return regexPattern.MatchString(p.Caption) && graphemeCount(p.Caption) <= CaptionMaxLength &&
+		utf8.RuneCountInString(p.Caption) <= CaptionMaxRunes
+}
+
+// IsValid checks the validity of the content. In particular, quote should be in its range of validity (it can be
+// empty). The quote is expected to be already NFC normalized.
+// Note that IDs are not checked.
+func (r *Repost) IsValid() bool {
+	if r.Quote == "" {
+		return true
+	}
+	regexPattern := regexp.MustCompile(MessageCommentRegex)
+	return regexPattern.MatchString(r.Quote) && graphemeCount(r.Quote) <= MessageMaxLength &&
+		utf8.RuneCountInString(r.Quote) <= MessageMaxRunes
+}
+
+// IsValid checks the validity of the content. In particular, the type should be one of the ReactionTypes.
+// Note that the user is not checked.
+func (r *Reaction) IsValid() bool {
+	for _, reaction := range ReactionTypes {
+		if r.Type == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid checks the validity of the content. In particular, the collection name should not be blank and it should be
+// in its range of validity. The name is expected to be already NFC normalized.
+// Note that IDs are not checked.
+func (c *Collection) IsValid() bool {
+	regexPattern := regexp.MustCompile(MessageCommentRegex)
+	return regexPattern.MatchString(c.Name) && strings.TrimSpace(c.Name) != "" &&
+		graphemeCount(c.Name) <= CollectionNameMaxLength && utf8.RuneCountInString(c.Name) <= CollectionNameMaxRunes
+}
+
+// IsValid checks the validity of the content. In particular, the role should be one of the Roles.
+// Note that the user and the status are not checked.
+func (a *Account) IsValid() bool {
+	for _, role := range Roles {
+		if a.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid checks the validity of the content. In particular, the reason should be one of the ReportReasons.
+// Note that IDs are not checked.
+func (r *Report) IsValid() bool {
+	for _, reason := range ReportReasons {
+		if r.Reason == reason {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"sync"
+	"time"
+)
+
+const (
+	// SuggestionsCacheTTL is how long the follow suggestions of a user are kept before being computed again
+	SuggestionsCacheTTL = 15 * time.Minute
+	// SuggestionsMaxCount is the maximum number of follow suggestions computed for a user
+	SuggestionsMaxCount uint64 = 50
+)
+
+// suggestionsCache keeps the follow suggestions of each user for SuggestionsCacheTTL, so that the follow graph is not
+// traversed on every request. Entries are dropped as soon as the user follows, unfollows, bans or unbans someone.
+type suggestionsCache struct {
+	mutex   sync.Mutex
+	entries map[uint64]suggestionsEntry
+}
+
+type suggestionsEntry struct {
+	computed    time.Time
+	suggestions []database.Suggestion
+}
+
+// newSuggestionsCache returns an empty suggestions cache
+func newSuggestionsCache() *suggestionsCache {
+	return &suggestionsCache{
+		entries: map[uint64]suggestionsEntry{},
+	}
+}
+
+// get returns the cached suggestions of uid, if they are not expired
+func (c *suggestionsCache) get(uid uint64) ([]database.Suggestion, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[uid]
+	if !ok || globaltime.Since(entry.computed) > SuggestionsCacheTTL {
+		return nil, false
+	}
+	return entry.suggestions, true
+}
+
+// set stores the suggestions of uid, removing the expired entries of other users
+func (c *suggestionsCache) set(uid uint64, suggestions []database.Suggestion) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, entry := range c.entries {
+		if globaltime.Since(entry.computed) > SuggestionsCacheTTL {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[uid] = suggestionsEntry{
+		computed:    globaltime.Now(),
+		suggestions: suggestions,
+	}
+}
+
+// invalidate removes the cached suggestions of the given users
+func (c *suggestionsCache) invalidate(uids ...uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, uid := range uids {
+		delete(c.entries, uid)
+	}
+}
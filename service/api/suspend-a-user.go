@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// suspendUser allows an administrator to suspend a user with a reason, for the number of hours in the "hours" field
+// of the body (0 for a suspension with no end). A suspended user cannot use the API until the end of the suspension.
+// The suspension is written in the audit trail.
+// If the user is not authorized or he is not an administrator, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the user is an administrator, the request will fail.
+// If the reason is not valid, the request will fail.
+// If the request is OK, it will return the Account{} object of the user.
+func (rt *_router) suspendUser(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in suspending user request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in suspending user request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is an administrator
+	if context.Role != database.RoleAdmin {
+		context.Logger.Error("The user that makes suspending user request is not an administrator")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for suspending user request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in suspending user request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	role, _, err := rt.getUserAccess(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving role in suspending user request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if role == database.RoleAdmin {
+		context.Logger.Error("Error in suspending user request! User is an administrator")
+		http.Error(w, "An administrator cannot be suspended", http.StatusForbidden)
+		return
+	}
+
+	var suspension struct {
+		Reason string `json:"reason"`
+		Hours  uint64 `json:"hours"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&suspension)
+	if err != nil {
+		// The body was not a parseable JSON, reject it
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// The reason is validated as a comment message
+	reason := Comment{Message: normalizeText(strings.TrimSpace(suspension.Reason))}
+	if !reason.IsValid() {
+		context.Logger.Error("Reason for suspension is not valid!")
+		http.Error(w, "The user cannot be suspended. Check the reason!", http.StatusBadRequest)
+		return
+	}
+
+	var until time.Time
+	if suspension.Hours > 0 {
+		until = globaltime.Now().Add(time.Duration(suspension.Hours) * time.Hour)
+	}
+
+	err = rt.db.SetUserStatus(uid, database.StatusSuspended, reason.Message, until)
+	if err != nil {
+		context.Logger.Error("Error suspending user.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong suspending the user.", http.StatusInternalServerError)
+		return
+	}
+
+	err = rt.db.AddModerationAction(database.ModerationAction{
+		Actor:  context.Uid,
+		Action: ModerationSuspend,
+		Uid:    uid,
+		Detail: reason.Message,
+	})
+	if err != nil {
+		context.Logger.Error("Error writing audit trail in suspending user request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong suspending the user.", http.StatusInternalServerError)
+		return
+	}
+
+	account, err := rt.getAccount(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving account in suspending user request.\nDetail: ", err.Error())
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]Account{"account": account})
+}
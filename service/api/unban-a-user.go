@@ -107,7 +107,7 @@ func (rt *_router) unbanUser(w http.ResponseWriter, r *http.Request, params http
 			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
 			return
 		}
-
+		rt.suggestions.invalidate(uid, muteduid)
 	}
 
 	w.WriteHeader(http.StatusNoContent)
@@ -122,6 +122,7 @@ func (rt *_router) unfollowUser(w http.ResponseWriter, r *http.Request, params h
 			http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
 			return
 		}
+		rt.suggestions.invalidate(uid)
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
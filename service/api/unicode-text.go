@@ -0,0 +1,159 @@
+// Package api
+/* This file consists in all the function used to validate and compare Unicode text (usernames and comments) */
+package api
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeText returns the NFC form of the text, so that the same visible string is always stored with the same
+// sequence of code points (e.g., "é" typed as a single character or as "e" + combining accent).
+func normalizeText(text string) string {
+	return norm.NFC.String(text)
+}
+
+// extendedPictographic is the Extended_Pictographic property of the Unicode emoji data (UTS #51), used to join emoji
+// ZWJ sequences in graphemeCount. The ranges include the code points reserved for future emoji, as the property does.
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00A9, 0x00A9, 1}, {0x00AE, 0x00AE, 1}, {0x203C, 0x203C, 1}, {0x2049, 0x2049, 1},
+		{0x2122, 0x2122, 1}, {0x2139, 0x2139, 1}, {0x2194, 0x2199, 1}, {0x21A9, 0x21AA, 1},
+		{0x231A, 0x231B, 1}, {0x2328, 0x2328, 1}, {0x2388, 0x2388, 1}, {0x23CF, 0x23CF, 1},
+		{0x23E9, 0x23F3, 1}, {0x23F8, 0x23FA, 1}, {0x24C2, 0x24C2, 1}, {0x25AA, 0x25AB, 1},
+		{0x25B6, 0x25B6, 1}, {0x25C0, 0x25C0, 1}, {0x25FB, 0x25FE, 1}, {0x2600, 0x2605, 1},
+		{0x2607, 0x2612, 1}, {0x2614, 0x2685, 1}, {0x2690, 0x2705, 1}, {0x2708, 0x2712, 1},
+		{0x2714, 0x2714, 1}, {0x2716, 0x2716, 1}, {0x271D, 0x271D, 1}, {0x2721, 0x2721, 1},
+		{0x2728, 0x2728, 1}, {0x2733, 0x2734, 1}, {0x2744, 0x2744, 1}, {0x2747, 0x2747, 1},
+		{0x274C, 0x274C, 1}, {0x274E, 0x274E, 1}, {0x2753, 0x2755, 1}, {0x2757, 0x2757, 1},
+		{0x2763, 0x2767, 1}, {0x2795, 0x2797, 1}, {0x27A1, 0x27A1, 1}, {0x27B0, 0x27B0, 1},
+		{0x27BF, 0x27BF, 1}, {0x2934, 0x2935, 1}, {0x2B05, 0x2B07, 1}, {0x2B1B, 0x2B1C, 1},
+		{0x2B50, 0x2B50, 1}, {0x2B55, 0x2B55, 1}, {0x3030, 0x3030, 1}, {0x303D, 0x303D, 1},
+		{0x3297, 0x3297, 1}, {0x3299, 0x3299, 1},
+	},
+	LatinOffset: 2,
+	R32: []unicode.Range32{
+		{0x1F000, 0x1F0FF, 1}, {0x1F10D, 0x1F10F, 1}, {0x1F12F, 0x1F12F, 1}, {0x1F16C, 0x1F171, 1},
+		{0x1F17E, 0x1F17F, 1}, {0x1F18E, 0x1F18E, 1}, {0x1F191, 0x1F19A, 1}, {0x1F1AD, 0x1F1E5, 1},
+		{0x1F201, 0x1F20F, 1}, {0x1F21A, 0x1F21A, 1}, {0x1F22F, 0x1F22F, 1}, {0x1F232, 0x1F23A, 1},
+		{0x1F23C, 0x1F23F, 1}, {0x1F249, 0x1F3FA, 1}, {0x1F400, 0x1F53D, 1}, {0x1F546, 0x1F64F, 1},
+		{0x1F680, 0x1F6FF, 1}, {0x1F774, 0x1F77F, 1}, {0x1F7D5, 0x1F7FF, 1}, {0x1F80C, 0x1F80F, 1},
+		{0x1F848, 0x1F84F, 1}, {0x1F85A, 0x1F85F, 1}, {0x1F888, 0x1F88F, 1}, {0x1F8AE, 0x1F8FF, 1},
+		{0x1F90C, 0x1F93A, 1}, {0x1F93C, 0x1F945, 1}, {0x1F947, 0x1FAFF, 1}, {0x1FC00, 0x1FFFD, 1},
+	},
+}
+
+// isGraphemeExtend checks if a character never starts a grapheme cluster: combining marks (variation selectors
+// included), emoji skin tone modifiers, emoji tags and the zero width joiner.
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r >= 0x1F3FB && r <= 0x1F3FF || // emoji skin tone modifiers
+		r >= 0xE0020 && r <= 0xE007F || // emoji tag sequences
+		r == '\u200d'
+}
+
+// hangulType returns the Hangul syllable type of a character: "L", "V" and "T" for the leading, vowel and trailing
+// conjoining jamo, "LV" and "LVT" for the precomposed syllables, "" otherwise.
+func hangulType(r rune) string {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return "L"
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return "V"
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return "T"
+	case r >= 0xAC00 && r <= 0xD7A3 && (r-0xAC00)%28 == 0:
+		return "LV"
+	case r >= 0xAC00 && r <= 0xD7A3:
+		return "LVT"
+	}
+	return ""
+}
+
+// graphemeCount returns the number of user-perceived characters (grapheme clusters) in the text, following the
+// extended grapheme cluster rules of UAX #29 that matter for the text of WASAPhoto (the ones used by Intl.Segmenter in
+// the web UI):
+//   - CR LF is a single character
+//   - combining marks, variation selectors, emoji modifiers, emoji tags and zero width joiners belong to the previous
+//     character
+//   - an emoji sequence joined by a zero width joiner is a single character, but only between two pictographic
+//     characters (a zero width joiner between letters doesn't join them)
+//   - regional indicator pairs (flags) and Hangul jamo sequences are single characters
+func graphemeCount(text string) int {
+	count := 0
+	var prev rune
+	pictographic := false // the current cluster is a pictographic character followed by extend characters
+	joined := false       // the current cluster is a pictographic character followed by a zero width joiner
+	regional := false     // the current cluster is a single regional indicator
+	for _, r := range text {
+		extend := false
+		switch {
+		case count == 0:
+		case prev == '\r' && r == '\n':
+			extend = true
+		case prev == '\r' || prev == '\n':
+		case isGraphemeExtend(r):
+			extend = true
+		case joined && unicode.Is(extendedPictographic, r):
+			extend = true
+		case regional && r >= 0x1F1E6 && r <= 0x1F1FF:
+			extend = true
+		default:
+			switch hangulType(prev) + ">" + hangulType(r) {
+			case "L>L", "L>V", "L>LV", "L>LVT", "LV>V", "V>V", "LV>T", "V>T", "LVT>T", "T>T":
+				extend = true
+			}
+		}
+
+		if extend {
+			switch {
+			case joined && unicode.Is(extendedPictographic, r):
+				pictographic = true
+			case !isGraphemeExtend(r):
+				pictographic = false
+			}
+			joined = pictographic && r == '\u200d'
+			regional = false
+		} else {
+			count++
+			pictographic = unicode.Is(extendedPictographic, r)
+			joined = false
+			regional = r >= 0x1F1E6 && r <= 0x1F1FF
+		}
+		prev = r
+	}
+	return count
+}
+
+// isSingleScript checks that all the letters in the text belong to a single writing system, so that a username
+// cannot mix look-alike characters from different alphabets (e.g., Latin "p" with Cyrillic "а").
+// Chinese, Japanese and Korean combinations are considered as a single script.
+func isSingleScript(text string) bool {
+	scripts := map[string]bool{}
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for name, table := range unicode.Scripts {
+			if name != "Common" && name != "Inherited" && unicode.Is(table, r) {
+				scripts[name] = true
+				break
+			}
+		}
+	}
+
+	if len(scripts) <= 1 {
+		return true
+	}
+
+	// Allowed combinations for CJK writing systems
+	for name := range scripts {
+		switch name {
+		case "Han", "Hiragana", "Katakana", "Hangul", "Bopomofo":
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "hello", 5},
+		{"precomposed accent", "café", 4},
+		{"combining accent", "café", 4},
+		{"leading combining mark", "́a", 2},
+		{"crlf", "a\r\nb", 3},
+		{"lf cr", "a\n\rb", 4},
+		{"variation selector", "❤️", 1},
+		{"skin tone", "\U0001f44d\U0001f3fd", 1},
+		{"zwj family", "\U0001f468‍\U0001f469‍\U0001f467", 1},
+		{"zwj with skin tone", "\U0001f469\U0001f3fd‍\U0001f4bb", 1},
+		{"zwj with variation selector", "❤️‍\U0001f525", 1},
+		{"zwj between letters", "a‍b", 2},
+		{"zwj after emoji before letter", "\U0001f600‍a", 2},
+		{"zwj after letter before emoji", "a‍\U0001f600", 2},
+		{"trailing zwj", "\U0001f600‍", 1},
+		{"letters joined by zwj", strings.Repeat("a‍", 400), 400},
+		{"flag", "\U0001f1ee\U0001f1f9", 1},
+		{"two flags", "\U0001f1ee\U0001f1f9\U0001f1eb\U0001f1f7", 2},
+		{"odd regional indicators", "\U0001f1ee\U0001f1f9\U0001f1eb", 2},
+		{"tag sequence", "\U0001f3f4\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f", 1},
+		{"hangul syllables", "한국", 2},
+		{"hangul jamo LV", "하", 1},
+		{"hangul jamo LVT", "한", 1},
+		{"hangul LV syllable and T", "한", 1},
+		{"hangul jamo two syllables", "한국", 2},
+		{"hangul LVT syllable and V", "한ᅡ", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graphemeCount(tt.text); got != tt.want {
+				t.Errorf("graphemeCount(%+q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"empty", "", ""},
+		{"ascii", "hello", "hello"},
+		{"combining accent", "café", "café"},
+		{"already composed", "café", "café"},
+		{"hangul jamo", "한", "한"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeText(tt.text); got != tt.want {
+				t.Errorf("normalizeText(%+q) = %+q, want %+q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSingleScript(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"empty", "", true},
+		{"latin", "simone", true},
+		{"latin with digits and symbols", "simone_04", true},
+		{"cyrillic", "павел", true},
+		{"latin and cyrillic", "pаypal", false},
+		{"latin and greek", "αlpha", false},
+		{"han and hiragana", "東京と", true},
+		{"han and katakana", "東京タワー", true},
+		{"hangul and han", "한国", true},
+		{"han and latin", "東京tokyo", false},
+		{"combining mark", "café", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSingleScript(tt.text); got != tt.want {
+				t.Errorf("isSingleScript(%+q) = %t, want %t", tt.text, got, tt.want)
+			}
+		})
+	}
+}
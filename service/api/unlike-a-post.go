@@ -8,7 +8,7 @@ import (
 	"strconv"
 )
 
-// unlikePost allows a user to remove like from a post.
+// unlikePost allows a user to remove like (the "heart" reaction) from a post. Other reactions are not removed.
 // If the user in not authorized, the request will fail.
 // If the user id doesn't exist, the request will fail.
 // If the post id doesn't exist, the request will fail.
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
 	"github.com/julienschmidt/httprouter"
 	"io"
 	"net/http"
@@ -12,10 +13,26 @@ import (
 	"strings"
 )
 
-// uploadPost allows to add a photo to the collection of posts.
+const (
+	// PostMaxMedia is the maximum number of photos in a post carousel
+	PostMaxMedia int = 10
+	// MultipartMaxMemory is the maximum size of a multipart form kept in memory, the rest is stored in temporary files
+	MultipartMaxMemory int64 = 32 << 20
+)
+
+// uploadedImage is a photo read from the upload request, with its declared Content-Type
+type uploadedImage struct {
+	data        []byte
+	contentType string
+}
+
+// uploadPost allows to add a post to the collection of posts. The post can be a single photo sent as the request
+// body, or a carousel of up to PostMaxMedia photos sent as the "media" fields of a multipart form (in order).
+// The "status" query parameter allows to save the post as a draft, visible only to the owner, or to schedule it for
+// the time in the "publish_datetime" query parameter (see setPostPublication); by default the post is published.
 // If the user in not authorized, the request will fail.
-// If the MIME type is not PNG or JPEG the request will fail.
-// The function will return the post ID created for new image
+// If the MIME type of a photo is not PNG or JPEG the request will fail.
+// The function will return the post ID created for new post and the media ID of each photo
 func (rt *_router) uploadPost(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
 	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
 	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
@@ -54,43 +71,88 @@ func (rt *_router) uploadPost(w http.ResponseWriter, r *http.Request, params htt
 		return
 	}
 
-	// read the body
-	body, err := io.ReadAll(r.Body)
+	// the post can be saved as a draft or scheduled
+	status, publishAt, err := parsePublication(r.URL.Query().Get("status"), r.URL.Query().Get("publish_datetime"))
+	if err != nil {
+		context.Logger.Error("Error in uploading post request! Not valid publication")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": err.Error(),
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// read the photos
+	var images []uploadedImage
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
 	}(r.Body)
 
-	if err != nil {
-		context.Logger.Error("Unable to read binary image for uploading post\nDetail: ", err.Error())
-		http.Error(w, "Something wrong uploading the image", http.StatusInternalServerError)
-		return
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		err = r.ParseMultipartForm(MultipartMaxMemory)
+		if err != nil {
+			context.Logger.Error("Unable to parse multipart form for uploading post\nDetail: ", err.Error())
+			http.Error(w, "Photos are not correctly sent", http.StatusBadRequest)
+			return
+		}
+		defer func() {
+			_ = r.MultipartForm.RemoveAll()
+		}()
+
+		files := r.MultipartForm.File["media"]
+		if len(files) == 0 || len(files) > PostMaxMedia {
+			context.Logger.Error("Wrong number of photos for uploading post")
+			http.Error(w, fmt.Sprintf("A post must have from 1 to %d photos", PostMaxMedia), http.StatusBadRequest)
+			return
+		}
+
+		for _, header := range files {
+			file, err := header.Open()
+			if err != nil {
+				context.Logger.Error("Unable to open binary image for uploading post\nDetail: ", err.Error())
+				http.Error(w, "Something wrong uploading the image", http.StatusInternalServerError)
+				return
+			}
+			data, err := io.ReadAll(file)
+			_ = file.Close()
+			if err != nil {
+				context.Logger.Error("Unable to read binary image for uploading post\nDetail: ", err.Error())
+				http.Error(w, "Something wrong uploading the image", http.StatusInternalServerError)
+				return
+			}
+			images = append(images, uploadedImage{data: data, contentType: header.Header.Get("Content-Type")})
+		}
+	} else {
+		// read the body
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			context.Logger.Error("Unable to read binary image for uploading post\nDetail: ", err.Error())
+			http.Error(w, "Something wrong uploading the image", http.StatusInternalServerError)
+			return
+		}
+		images = append(images, uploadedImage{data: body, contentType: r.Header.Get("Content-Type")})
 	}
 
-	// check if the Content-Type in the request is correct and if the binary format is correct
-	var imageType string
-	contentType := r.Header.Get("Content-Type")
-	if strings.HasPrefix(contentType, "image/png") {
-		imageType = detectImageType(body, &context)
-		if imageType == "png" {
-			context.Logger.Info("Image is correct PNG")
-		} else {
-			context.Logger.Error("Content-Type is a PNG but the body is not")
+	// check if the Content-Type of each photo is correct and if the binary format is correct
+	var media []database.Media
+	for _, uploaded := range images {
+		imageType := checkImageType(uploaded.data, uploaded.contentType, &context)
+		if imageType == "" {
 			http.Error(w, "File is not supported", http.StatusBadRequest)
 			return
 		}
-	} else if strings.HasPrefix(contentType, "image/jpeg") {
-		imageType = detectImageType(body, &context)
-		if imageType == "jpeg" {
-			context.Logger.Info("Image is a correct JPEG")
-		} else {
-			context.Logger.Error("Content-Type is a JPEG but the body is not")
+
+		width, height, err := imageSize(bytes.NewReader(uploaded.data))
+		if err != nil {
+			context.Logger.Error("Unable to read the size of the image\nDetail: ", err.Error())
 			http.Error(w, "File is not supported", http.StatusBadRequest)
 			return
 		}
-	} else {
-		context.Logger.Error("File format is not valid")
-		http.Error(w, "File is not supported", http.StatusBadRequest)
-		return
+
+		media = append(media, database.Media{Format: imageType, Width: width, Height: height})
 	}
 
 	// check if media/img folders already exists.
@@ -104,7 +166,7 @@ func (rt *_router) uploadPost(w http.ResponseWriter, r *http.Request, params htt
 	}
 
 	// create post and get the post id
-	imageId, err := rt.db.AddPost(uid)
+	postid, err := rt.db.AddPost(uid, media, status, publishAt)
 
 	if err != nil {
 		message := fmt.Sprintf("Error creating new post for user %d\nDetail: ", uid)
@@ -113,27 +175,48 @@ func (rt *_router) uploadPost(w http.ResponseWriter, r *http.Request, params htt
 		return
 	}
 
-	filename := strconv.FormatUint(imageId, 10)
-	filename += "." + imageType
-
-	// because r.Body was already read before, we need to put to pointer to the start
-	r.Body = io.NopCloser(bytes.NewReader(body))
-	err = saveImage(r.Body, "media/img", filename)
+	// each image file is named after its media id
+	media, err = rt.db.GetPostMedia(postid)
+	if err == nil {
+		for i, item := range media {
+			filename := strconv.FormatUint(item.Mediaid, 10) + "." + item.Format
+			err = saveImage(bytes.NewReader(images[i].data), "media/img", filename)
+			if err != nil {
+				break
+			}
+		}
+	}
 
 	if err != nil {
 		context.Logger.Error("Error saving image on the disk\nDetail: ", err.Error())
+		// Remove the incomplete post
+		_ = deleteMediaImages(media)
+		_, _ = rt.db.RemovePost(postid, uid)
 		http.Error(w, "Somenthing wrong uploading your post", http.StatusInternalServerError)
 		return
 	}
 
-	// image correctly saved on disk
-	// now return the postid to the client
-	w.Header().Set("Content-Type", "application/json")
-
-	result := map[string]uint64{
-		"postid": imageId,
+	// images correctly saved on disk
+	// now return the postid and the media to the client
+	result := struct {
+		Postid    uint64  `json:"postid"`
+		Media     []Media `json:"media"`
+		Status    string  `json:"status,omitempty"`
+		PublishAt string  `json:"publish_datetime,omitempty"`
+	}{
+		Postid: postid,
+	}
+	if status != database.PostPublished {
+		result.Status = status
+		result.PublishAt = r.URL.Query().Get("publish_datetime")
+	}
+	for _, item := range media {
+		var mediaAPI Media
+		_ = mediaAPI.FromDatabase(item)
+		result.Media = append(result.Media, mediaAPI)
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(result)
 }
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Simone0401/WASAPhoto/service/api/reqcontext"
+	"github.com/Simone0401/WASAPhoto/service/database"
+	"github.com/Simone0401/WASAPhoto/service/globaltime"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// uploadStory allows a user to add a story, a photo that is visible to his followers for StoryDuration.
+// If the user in not authorized, the request will fail.
+// If the user id doesn't exist, the request will fail.
+// If the MIME type is not PNG or JPEG the request will fail.
+// The function will return the created Story{} object.
+func (rt *_router) uploadStory(w http.ResponseWriter, r *http.Request, params httprouter.Params, context reqcontext.RequestContext) {
+	// The User ID in the path is a 64-bit unsigned integer. Let's parse it.
+	uid, err := strconv.ParseUint(params.ByName("uid"), 10, 64)
+
+	if err != nil {
+		context.Logger.Error("Error parsing uid in uploading story request")
+		w.WriteHeader(http.StatusBadRequest)
+
+		response := map[string]string{
+			"error": "not correct format for uid",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the Bearer Authorization Token is set
+	if !rt.isAuthorized(r.Header) {
+		context.Logger.Error("The bearer format token is not valid in uploading story request!")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		response := map[string]string{
+			"error": "log to do action",
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// check if the current user is authorized
+	currentUid := context.Uid
+	if currentUid != uid {
+		context.Logger.Error("Error retrieving the current uid that makes uploading story request")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// check if the user exists
+	check, err := rt.db.CheckExistsByUID(uid)
+	if err != nil {
+		context.Logger.Error("Error retrieving information on UID for uploading story request!")
+		http.Error(w, "Something wrong in the server", http.StatusInternalServerError)
+		return
+	}
+
+	if !check {
+		context.Logger.Error("Error in uploading story request! User doesn't exist!")
+		http.Error(w, "User seems not exist.", http.StatusNotFound)
+		return
+	}
+
+	// read the body
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(r.Body)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		context.Logger.Error("Unable to read binary image for uploading story\nDetail: ", err.Error())
+		http.Error(w, "Something wrong uploading the image", http.StatusInternalServerError)
+		return
+	}
+
+	// check if the Content-Type is correct and if the binary format is correct
+	imageType := checkImageType(body, r.Header.Get("Content-Type"), &context)
+	if imageType == "" {
+		http.Error(w, "File is not supported", http.StatusBadRequest)
+		return
+	}
+
+	width, height, err := imageSize(bytes.NewReader(body))
+	if err != nil {
+		context.Logger.Error("Unable to read the size of the image\nDetail: ", err.Error())
+		http.Error(w, "File is not supported", http.StatusBadRequest)
+		return
+	}
+
+	// check if media/stories folders already exists.
+	// Create them if they are not present
+	err = createDirs(StoryImagesDirectory)
+	if err != nil {
+		context.Logger.Error("Error creating media/stories folders\nDetail: ", err.Error())
+		http.Error(w, "Error storing your image", http.StatusInternalServerError)
+		return
+	}
+
+	now := globaltime.Now()
+	storyid, err := rt.db.AddStory(uid, database.Media{Format: imageType, Width: width, Height: height}, now, now.Add(StoryDuration))
+	if err != nil {
+		message := fmt.Sprintf("Error creating new story for user %d\nDetail: ", uid)
+		context.Logger.Error(message, err.Error())
+		http.Error(w, "Somenthing wrong adding the story", http.StatusInternalServerError)
+		return
+	}
+
+	storyDB, err := rt.db.GetStory(storyid)
+	if err == nil {
+		// the image file is named after the story id
+		err = saveImage(bytes.NewReader(body), StoryImagesDirectory, strconv.FormatUint(storyid, 10)+"."+imageType)
+	}
+
+	if err != nil {
+		context.Logger.Error("Error saving story image on the disk\nDetail: ", err.Error())
+		// Remove the incomplete story
+		_ = deleteStoryImage(storyDB)
+		_ = rt.db.RemoveStory(storyid)
+		http.Error(w, "Somenthing wrong uploading your story", http.StatusInternalServerError)
+		return
+	}
+
+	var story Story
+	_ = story.FromDatabase(storyDB)
+	story.Datetime, _ = formatDatetime(story.Datetime)
+	story.Expiration, _ = formatDatetime(story.Expiration)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]Story{"story": story})
+}
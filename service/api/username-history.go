@@ -0,0 +1,38 @@
+// Package api
+/* This file consists in all the function used to manage the changes and the uniqueness of the usernames */
+package api
+
+import "github.com/Simone0401/WASAPhoto/service/globaltime"
+
+// usernameReserved checks if the username (or a username confusable with it) has been left by a user different from
+// uid during the cooldown, so that it cannot be taken yet.
+func (rt *_router) usernameReserved(username string, skeleton string, uid uint64) (bool, error) {
+	if rt.usernameCooldown <= 0 {
+		return false, nil
+	}
+	return rt.db.CheckUsernameReleased(username, skeleton, uid, globaltime.Now().Add(-rt.usernameCooldown))
+}
+
+// renamedRecently checks if the user has changed his username less than the change interval ago.
+func (rt *_router) renamedRecently(uid uint64) (bool, error) {
+	if rt.usernameChangeInterval <= 0 {
+		return false, nil
+	}
+	return rt.db.CheckRenamedSince(uid, globaltime.Now().Add(-rt.usernameChangeInterval))
+}
+
+// reportUsernameCollisions logs the users whose username is the same of an older user's one except for the case, as
+// they have been created before usernames were case-insensitive. They can log in only with their exact username until
+// they (or an administrator) change it.
+func (rt *_router) reportUsernameCollisions() {
+	collisions, err := rt.db.GetUsernameCollisions()
+	if err != nil {
+		rt.baseLogger.WithError(err).Error("error checking username collisions")
+		return
+	}
+
+	for _, collision := range collisions {
+		rt.baseLogger.Warnf("username %q of user %d collides with username %q of user %d: it should be changed",
+			collision.User.Username, collision.User.Userid, collision.Holder.Username, collision.Holder.Userid)
+	}
+}
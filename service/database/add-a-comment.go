@@ -1,6 +1,9 @@
 package database
 
-func (db *appdbimpl) AddComment(userid uint64, postid uint64, message string) (Comment, error) {
+// AddComment allows to add a comment under a post, indexing the hashtags and the mentions it contains
+// and its text for the search.
+// Function will return the created comment.
+func (db *appdbimpl) AddComment(userid uint64, postid uint64, message string, tags []string, mentions []Mention) (Comment, error) {
 	var commentId uint64
 	err := db.c.QueryRow("SELECT COUNT(commentid) FROM comment").Scan(&commentId)
 	if err != nil {
@@ -15,15 +18,48 @@ func (db *appdbimpl) AddComment(userid uint64, postid uint64, message string) (C
 	}
 
 	commentId = commentId + 1
-	_, err = db.c.Exec("INSERT INTO comment(commentid, message, timestamp, postid, uid) VALUES (?, ?, datetime('now', '+1 hours'), ?, ?)", commentId, message, postid, userid)
+
+	tx, err := db.c.Begin()
+	if err != nil {
+		return Comment{}, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec("INSERT INTO comment(commentid, message, timestamp, postid, uid) VALUES (?, ?, datetime('now', '+1 hours'), ?, ?)", commentId, message, postid, userid)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	err = insertPostTags(tx, postid, commentId, tags)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	err = indexPostText(tx, postid, commentId, message)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	for _, mention := range mentions {
+		_, err = tx.Exec("INSERT INTO mention (commentid, uid, position, length) VALUES (?, ?, ?, ?)", commentId, mention.Uid, mention.Offset, mention.Length)
+		if err != nil {
+			return Comment{}, err
+		}
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return Comment{}, err
 	}
 
 	var comment Comment
-	err = db.c.QueryRow("SELECT * FROM comment WHERE commentid = ?", commentId).Scan(&comment.Commentid, &comment.Message, &comment.Datetime, &comment.Postid, &comment.Userid)
+	err = db.c.QueryRow("SELECT commentid, message, timestamp, postid, uid FROM comment WHERE commentid = ?", commentId).Scan(&comment.Commentid, &comment.Message, &comment.Datetime, &comment.Postid, &comment.Userid)
 	if err != nil {
 		return Comment{}, err
 	}
+
+	comment.Mentions, err = db.GetCommentMentions(commentId)
 	return comment, err
 }
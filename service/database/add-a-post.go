@@ -1,23 +1,51 @@
 package database
 
-// AddPost allows to create a new post for a specific user.
+import (
+	"database/sql"
+	"time"
+)
+
+// AddPost allows to create a new post for a specific user, with its media in the order of the carousel. The post has
+// the specified status: a scheduled post is published at publishAt.
 // Function will return the created new post id .
-func (db *appdbimpl) AddPost(userid uint64) (uint64, error) {
+func (db *appdbimpl) AddPost(userid uint64, media []Media, status string, publishAt time.Time) (uint64, error) {
 	var maxId uint64
 
+	tx, err := db.c.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// RECOVER MAX(ID)
-	err := db.c.QueryRow("SELECT MAX(postid) FROM post").Scan(&maxId)
+	err = tx.QueryRow("SELECT MAX(postid) FROM post").Scan(&maxId)
 	if err != nil {
 		maxId = 0
 	}
 
 	postid := maxId + 1
 
-	_, err = db.c.Exec("INSERT INTO post VALUES (?, ?, (SELECT datetime('now', '+1 hours')))", postid, userid)
+	var publishAtColumn sql.NullString
+	if status == PostScheduled {
+		publishAtColumn = sql.NullString{String: databaseTime(publishAt), Valid: true}
+	}
+
+	_, err = tx.Exec("INSERT INTO post (postid, uid, timestamp, status, publish_at) "+
+		"VALUES (?, ?, (SELECT datetime('now', '+1 hours')), ?, ?)", postid, userid, status, publishAtColumn)
 
 	if err != nil {
 		return 0, err
 	}
 
-	return postid, err
+	for position, item := range media {
+		_, err = tx.Exec("INSERT INTO post_media (postid, position, format, width, height) VALUES (?, ?, ?, ?, ?)",
+			postid, position, item.Format, item.Width, item.Height)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return postid, tx.Commit()
 }
@@ -0,0 +1,20 @@
+package database
+
+// AddReport allows a user to report a post (if report.Commentid is 0) or a comment of the post with a reason.
+// Request will fail if the user has already reported the content.
+// Function will return the created report.
+func (db *appdbimpl) AddReport(report Report) (Report, error) {
+	result, err := db.c.Exec("INSERT INTO report (uid, postid, commentid, reason, status, timestamp) "+
+		"VALUES (?, ?, ?, ?, ?, datetime('now', '+1 hours'))", report.Reporter.Userid, report.Postid, report.Commentid,
+		report.Reason, ReportOpen)
+	if err != nil {
+		return Report{}, err
+	}
+
+	reportid, err := result.LastInsertId()
+	if err != nil {
+		return Report{}, err
+	}
+
+	return db.GetReport(uint64(reportid))
+}
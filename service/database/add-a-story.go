@@ -0,0 +1,21 @@
+package database
+
+import "time"
+
+// AddStory allows to create a new story for a specific user, uploaded at now and visible until expiration.
+// Only Format, Width and Height of media are used.
+// Function will return the created new story id.
+func (db *appdbimpl) AddStory(uid uint64, media Media, now time.Time, expiration time.Time) (uint64, error) {
+	result, err := db.c.Exec("INSERT INTO story (uid, format, width, height, timestamp, expiration) VALUES (?, ?, ?, ?, ?, ?)",
+		uid, media.Format, media.Width, media.Height, databaseTime(now), databaseTime(expiration))
+	if err != nil {
+		return 0, err
+	}
+
+	storyid, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(storyid), nil
+}
@@ -0,0 +1,21 @@
+package database
+
+import "time"
+
+// AddExport allows to request a new export of the personal data of a user at now. The export is pending until its
+// archive is built.
+// Function will return the created export.
+func (db *appdbimpl) AddExport(uid uint64, now time.Time) (Export, error) {
+	result, err := db.c.Exec("INSERT INTO export (uid, status, timestamp) VALUES (?, ?, ?)", uid, ExportPending,
+		databaseTime(now))
+	if err != nil {
+		return Export{}, err
+	}
+
+	exportid, err := result.LastInsertId()
+	if err != nil {
+		return Export{}, err
+	}
+
+	return scanExport(db.c.QueryRow(exportsQuery+"WHERE exportid = ?", exportid))
+}
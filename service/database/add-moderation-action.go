@@ -0,0 +1,9 @@
+package database
+
+// AddModerationAction allows to write a moderation action in the audit trail.
+func (db *appdbimpl) AddModerationAction(action ModerationAction) error {
+	_, err := db.c.Exec("INSERT INTO moderation_action (actor, action, uid, reportid, postid, commentid, detail, timestamp) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?, datetime('now', '+1 hours'))", action.Actor, action.Action, action.Uid, action.Reportid,
+		action.Postid, action.Commentid, action.Detail)
+	return err
+}
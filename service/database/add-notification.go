@@ -0,0 +1,9 @@
+package database
+
+// AddNotification allows to add a notification for a user.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) AddNotification(notification Notification) error {
+	_, err := db.c.Exec("INSERT INTO notification (uid, type, actor, postid, commentid, timestamp) VALUES (?, ?, ?, ?, ?, datetime('now', '+1 hours'))",
+		notification.Uid, notification.Type, notification.Actor, notification.Postid, notification.Commentid)
+	return err
+}
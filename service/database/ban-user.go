@@ -2,11 +2,24 @@ package database
 
 // BanUser allows specified uid user to ban muteduid user
 // Request will fail if specified uid user has already muted the muteduid in database
+// The reposts of the posts of uid made by muteduid are removed.
 // Success request will return true
 func (db *appdbimpl) BanUser(userid uint64, muteduid uint64) (bool, error) {
-	_, err := db.c.Exec("INSERT INTO ban (uid, buid) VALUES (?, ?)", userid, muteduid)
+	tx, err := db.c.Begin()
 	if err != nil {
 		return false, err
 	}
-	return true, err
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec("INSERT INTO ban (uid, buid) VALUES (?, ?)", userid, muteduid)
+	if err != nil {
+		return false, err
+	}
+	_, err = tx.Exec("DELETE FROM repost WHERE uid = ? AND postid IN (SELECT postid FROM post WHERE uid = ?)", muteduid, userid)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
 }
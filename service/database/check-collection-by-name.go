@@ -0,0 +1,9 @@
+package database
+
+// CheckExistsCollectionName checks if a user already has a collection with the specified name.
+// Request will return true if the collection exists, otherwise false.
+func (db *appdbimpl) CheckExistsCollectionName(uid uint64, name string) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM collection WHERE uid = ? AND name = ?", uid, name).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,9 @@
+package database
+
+// CheckCollectionOwner checks if a specified user is the owner of a specified collection.
+// Request will return true if user is the owner, otherwise false (even if the collection doesn't exist).
+func (db *appdbimpl) CheckCollectionOwner(collectionid uint64, userid uint64) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM collection WHERE collectionid = ? AND uid = ?", collectionid, userid).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,9 @@
+package database
+
+// CheckCommentOnPost checks if a specified comment is under a specified post.
+// Request will return true if the comment is under the post, otherwise false.
+func (db *appdbimpl) CheckCommentOnPost(commentid uint64, postid uint64) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM comment WHERE commentid = ? AND postid = ?", commentid, postid).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,14 @@
+package database
+
+// CheckContentHidden checks if a post (if commentid is 0) or a comment is hidden by the moderation.
+// Request will fail if the content doesn't exist.
+func (db *appdbimpl) CheckContentHidden(postid uint64, commentid uint64) (bool, error) {
+	var hidden bool
+	var err error
+	if commentid == 0 {
+		err = db.c.QueryRow("SELECT hidden FROM post WHERE postid = ?", postid).Scan(&hidden)
+	} else {
+		err = db.c.QueryRow("SELECT hidden FROM comment WHERE commentid = ? AND postid = ?", commentid, postid).Scan(&hidden)
+	}
+	return hidden, err
+}
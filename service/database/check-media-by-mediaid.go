@@ -0,0 +1,9 @@
+package database
+
+// CheckMediaByMediaid checks if a media exists.
+// Request will return true if the media exists, otherwise false.
+func (db *appdbimpl) CheckMediaByMediaid(mediaid uint64) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM post_media WHERE mediaid = ?", mediaid).Scan(&count)
+	return count > 0, err
+}
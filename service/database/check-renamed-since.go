@@ -0,0 +1,11 @@
+package database
+
+import "time"
+
+// CheckRenamedSince checks if the user uid has changed his username after since.
+func (db *appdbimpl) CheckRenamedSince(uid uint64, since time.Time) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM username_history WHERE uid = ? AND released > ?",
+		uid, databaseTime(since)).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,10 @@
+package database
+
+// CheckReport checks if a user has already reported a post (if commentid is 0) or a comment.
+// Function will return true if the content is already reported by the user.
+func (db *appdbimpl) CheckReport(uid uint64, postid uint64, commentid uint64) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM report WHERE uid = ? AND postid = ? AND commentid = ?", uid, postid,
+		commentid).Scan(&count)
+	return count > 0, err
+}
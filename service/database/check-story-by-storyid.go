@@ -0,0 +1,14 @@
+package database
+
+import "time"
+
+// CheckStoryByStoryid allows to check if a story exists and it's not expired at now.
+// Function will return true if the story is still visible, false otherwise
+func (db *appdbimpl) CheckStoryByStoryid(storyid uint64, now time.Time) (bool, error) {
+	var count uint64
+	err := db.c.QueryRow("SELECT COUNT(*) FROM story WHERE storyid = ? AND expiration > ?", storyid, databaseTime(now)).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -1,16 +1,13 @@
 package database
 
-// CheckLike allows to check if a like is already put from uid to a specified post.
+// CheckLike allows to check if a like (the ReactionHeart reaction) is already put from uid to a specified post.
 // Function will return nil if no errors are present, an error otherwise.
 func (db *appdbimpl) CheckLike(postid uint64, userid uint64) (bool, error) {
-	var count uint64
-
-	// Check if like is already put
-	err := db.c.QueryRow("SELECT COUNT(*) FROM like WHERE uid = ? AND postid = ?", userid, postid).Scan(&count)
+	reaction, err := db.GetReaction(postid, 0, userid)
 	if err != nil {
 		return false, err
 	}
 
 	// Like already put
-	return count > 0, nil
+	return reaction == ReactionHeart, nil
 }
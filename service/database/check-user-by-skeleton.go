@@ -0,0 +1,9 @@
+package database
+
+// CheckExistsBySkeleton checks if a user different from uid has a username with the specified skeleton, that is a
+// username visually confusable with the one the skeleton has been computed from.
+func (db *appdbimpl) CheckExistsBySkeleton(skeleton string, uid uint64) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM user WHERE skeleton = ? AND uid != ?", skeleton, uid).Scan(&count)
+	return count > 0, err
+}
@@ -1,9 +1,11 @@
 package database
 
-// CheckExistsByUID checks if a user id is already present in database.
+// CheckExistsByUID checks if a user id is already present in database. Deleted users (and the ones that are being
+// deleted) don't exist anymore.
 // Request will fail if uid doesn't exist
 func (db *appdbimpl) CheckExistsByUID(uid uint64) (bool, error) {
 	var count int
-	err := db.c.QueryRow("SELECT COUNT(*) FROM user WHERE uid = ?", uid).Scan(&count)
+	err := db.c.QueryRow("SELECT COUNT(*) FROM user WHERE uid = ? AND status NOT IN (?, ?)", uid, StatusDeleting,
+		StatusDeleted).Scan(&count)
 	return count > 0, err
 }
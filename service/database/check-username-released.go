@@ -0,0 +1,12 @@
+package database
+
+import "time"
+
+// CheckUsernameReleased checks if a user different from uid has left, after since, the username (even with a different
+// case) or a username with the same skeleton (that is visually confusable with it).
+func (db *appdbimpl) CheckUsernameReleased(username string, skeleton string, uid uint64, since time.Time) (bool, error) {
+	var count int
+	err := db.c.QueryRow("SELECT COUNT(*) FROM username_history WHERE (username_key = ? OR skeleton = ?) "+
+		"AND uid != ? AND released > ?", usernameKey(username), skeleton, uid, databaseTime(since)).Scan(&count)
+	return count > 0, err
+}
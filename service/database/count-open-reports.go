@@ -0,0 +1,9 @@
+package database
+
+// CountOpenReports allows to get the number of open reports of a post (if commentid is 0) or of a comment.
+func (db *appdbimpl) CountOpenReports(postid uint64, commentid uint64) (uint64, error) {
+	var count uint64
+	err := db.c.QueryRow("SELECT COUNT(*) FROM report WHERE postid = ? AND commentid = ? AND status = ?", postid,
+		commentid, ReportOpen).Scan(&count)
+	return count, err
+}
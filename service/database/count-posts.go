@@ -0,0 +1,8 @@
+package database
+
+// CountPosts allows to get the number of posts uploaded by a user, hidden posts included.
+func (db *appdbimpl) CountPosts(uid uint64) (uint64, error) {
+	var count uint64
+	err := db.c.QueryRow("SELECT COUNT(*) FROM post WHERE uid = ?", uid).Scan(&count)
+	return count, err
+}
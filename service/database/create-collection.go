@@ -0,0 +1,17 @@
+package database
+
+// CreateCollection allows a user to create a new collection of saved posts.
+// Request will fail if the user already has a collection with the same name.
+func (db *appdbimpl) CreateCollection(uid uint64, name string) (Collection, error) {
+	result, err := db.c.Exec("INSERT INTO collection (uid, name, timestamp) VALUES (?, ?, datetime('now', '+1 hours'))", uid, name)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	collectionid, err := result.LastInsertId()
+	if err != nil {
+		return Collection{}, err
+	}
+
+	return db.GetCollection(uint64(collectionid))
+}
@@ -1,20 +1,29 @@
 package database
 
-// CreateUser allows to create a user from a username.
-// Request will fail if username already exists.
-func (db *appdbimpl) CreateUser(username string) (User, error) {
+// CreateUser allows to create a user from a username and its skeleton, indexing the username for the search.
+// Request will fail if username (even with a different case) or skeleton already exists.
+func (db *appdbimpl) CreateUser(username string, skeleton string) (User, error) {
 	var user User
 	var maxId uint64
 
+	tx, err := db.c.Begin()
+	if err != nil {
+		return User{0, ""}, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
 	// RECOVER MAX(ID)
-	err := db.c.QueryRow("SELECT MAX(uid) FROM user").Scan(&maxId)
+	err = tx.QueryRow("SELECT MAX(uid) FROM user").Scan(&maxId)
 	if err != nil {
 		maxId = 0
 	}
 
 	setId := maxId + 1
 
-	result, err := db.c.Exec("INSERT INTO user (uid, username) VALUES (?, ?)", setId, username)
+	result, err := tx.Exec("INSERT INTO user (uid, username, skeleton, username_key) VALUES (?, ?, ?, ?)", setId,
+		username, skeleton, usernameKey(username))
 
 	if err != nil {
 		return User{0, ""}, err
@@ -27,9 +36,14 @@ func (db *appdbimpl) CreateUser(username string) (User, error) {
 	}
 
 	if check > 0 {
+		err = indexUsername(tx, setId, username)
+		if err != nil {
+			return User{0, ""}, err
+		}
+
 		user.Username = username
 		user.Userid = setId
 	}
 
-	return user, err
+	return user, tx.Commit()
 }
@@ -0,0 +1,16 @@
+package database
+
+import "time"
+
+// databaseTime allows to format a time (usually globaltime.Now()) to be stored in a DATETIME column. Time is formatted
+// in the same clock of datetime('now', '+1 hours') used for the other timestamps, so that they can be compared.
+func databaseTime(t time.Time) string {
+	return t.UTC().Add(time.Hour).Format("2006-01-02 15:04:05")
+}
+
+// ParseTime allows to parse a time in the format "2006-01-02 15:04:05" of the timestamps shown to the users, which are
+// in the clock of the DATETIME columns (see databaseTime).
+func ParseTime(datetime string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02 15:04:05", datetime)
+	return t.Add(-time.Hour), err
+}
@@ -34,18 +34,21 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // AppDatabase is the high level interface for the DB
 type AppDatabase interface {
 	GetUsername(uid uint64) (string, error)
-	SetUsername(uid uint64, name string) error
+	SetUsername(uid uint64, name string, skeleton string, now time.Time) error
 	GetUserByID(uid uint64) (User, error)
 	GetUserByUsername(username string) (User, error)
-	SearchUserByUsername(username string) ([]User, error)
+	SearchUserByUsername(username string, uid uint64, offset uint64, limit uint64) ([]User, error)
 	CheckExistsByUsername(username string) (bool, error)
+	CheckExistsBySkeleton(skeleton string, uid uint64) (bool, error)
 	CheckExistsByUID(uid uint64) (bool, error)
-	CreateUser(username string) (User, error)
+	CreateUser(username string, skeleton string) (User, error)
 	HasFollowed(userid uint64, followuid uint64) (bool, error)
 	HasBanned(userid uint64, banneduid uint64) (bool, error)
 	FollowUser(userid uint64, banneduid uint64) (bool, error)
@@ -53,19 +56,35 @@ type AppDatabase interface {
 	HasMuted(userid uint64, muteduid uint64) (bool, error)
 	BanUser(userid uint64, muteduid uint64) (bool, error)
 	UnbanUser(userid uint64, muteduid uint64) (bool, error)
-	AddPost(userid uint64) (uint64, error)
+	AddPost(userid uint64, media []Media, status string, publishAt time.Time) (uint64, error)
+	GetPostMedia(postid uint64) ([]Media, error)
+	CheckMediaByMediaid(mediaid uint64) (bool, error)
+	GetMediaPostid(mediaid uint64) (uint64, error)
+	GetMediaWithoutInfo() ([]Media, error)
+	SetMediaInfo(media Media) error
+	SetPostCaption(postid uint64, caption string, tags []string) error
 	CheckPostByPostid(postid uint64) (bool, error)
-	RemoveCommentsFromPost(postid uint64) error
-	RemoveLikesFromPost(postid uint64) error
-	RemovePost(postid uint64, userid uint64) error
+	RemovePost(postid uint64, userid uint64) ([]Media, error)
 	CheckLike(postid uint64, userid uint64) (bool, error)
 	LikePost(postid uint64, userid uint64) error
 	UnlikePost(postid uint64, userid uint64) error
-	AddComment(userid uint64, postid uint64, message string) (Comment, error)
+	GetReaction(postid uint64, commentid uint64, userid uint64) (string, error)
+	SetReaction(postid uint64, commentid uint64, userid uint64, reaction string) error
+	RemoveReaction(postid uint64, commentid uint64, userid uint64) error
+	GetReactions(postid uint64, commentid uint64) (map[string]uint64, error)
+	LikeComment(postid uint64, commentid uint64, userid uint64) error
+	UnlikeComment(postid uint64, commentid uint64, userid uint64) error
+	GetLikedComments(postid uint64, userid uint64) ([]uint64, error)
+	SetRepost(userid uint64, postid uint64, quote string) (Repost, error)
+	RemoveRepost(userid uint64, postid uint64) error
+	GetRepost(repostid uint64) (Repost, error)
+	AddComment(userid uint64, postid uint64, message string, tags []string, mentions []Mention) (Comment, error)
 	CheckCommentOwner(commentid uint64, userid uint64) (bool, error)
 	CheckCommentByCommentid(commentid uint64) (bool, error)
+	CheckCommentOnPost(commentid uint64, postid uint64) (bool, error)
 	DeleteComment(commentid uint64) error
 	GetUserStream(uid uint64) ([]Post, error)
+	GetExplorePosts(uid uint64, hours uint64, offset uint64, limit uint64) ([]Post, error)
 	GetFollowers(uid uint64) ([]uint64, error)
 	GetPostLikes(postid uint64) ([]uint64, error)
 	GetPostComments(postid uint64) ([]Comment, error)
@@ -73,6 +92,71 @@ type AppDatabase interface {
 	GetProfileInfo(uid uint64) (Profile, error)
 	GetProfilePosts(uid uint64) ([]Post, error)
 	GetPost(postid uint64) (Post, error)
+	GetTagPosts(tag string, uid uint64, offset uint64, limit uint64) ([]Post, error)
+	GetTrendingTags(hours uint64, limit uint64) ([]Tag, error)
+	GetCommentMentions(commentid uint64) ([]Mention, error)
+	AddNotification(notification Notification) error
+	GetNotifications(uid uint64, offset uint64, limit uint64) ([]Notification, error)
+	GetFollowSuggestions(uid uint64, limit uint64) ([]Suggestion, error)
+	SearchPosts(text string, uid uint64, offset uint64, limit uint64) ([]PostMatch, error)
+	SavePost(uid uint64, postid uint64, collectionid uint64) error
+	UnsavePost(uid uint64, postid uint64) error
+	GetSavedPosts(uid uint64, collectionid uint64, offset uint64, limit uint64) ([]Post, error)
+	CreateCollection(uid uint64, name string) (Collection, error)
+	CheckCollectionOwner(collectionid uint64, userid uint64) (bool, error)
+	CheckExistsCollectionName(uid uint64, name string) (bool, error)
+	GetCollection(collectionid uint64) (Collection, error)
+	GetCollections(uid uint64) ([]Collection, error)
+	RenameCollection(collectionid uint64, name string) error
+	DeleteCollection(collectionid uint64) error
+	AddStory(uid uint64, media Media, now time.Time, expiration time.Time) (uint64, error)
+	CheckStoryByStoryid(storyid uint64, now time.Time) (bool, error)
+	GetStory(storyid uint64) (Story, error)
+	GetUserStories(uid uint64, now time.Time) ([]Story, error)
+	GetStreamStories(uid uint64, now time.Time) ([]Story, error)
+	SetStorySeen(storyid uint64, uid uint64, now time.Time) error
+	GetStoryViewers(storyid uint64) ([]StoryView, error)
+	GetExpiredStories(now time.Time) ([]Story, error)
+	RemoveStory(storyid uint64) error
+	AddReport(report Report) (Report, error)
+	CheckReport(uid uint64, postid uint64, commentid uint64) (bool, error)
+	CountOpenReports(postid uint64, commentid uint64) (uint64, error)
+	GetReport(reportid uint64) (Report, error)
+	GetReports(status string, offset uint64, limit uint64) ([]Report, error)
+	SetReportsStatus(postid uint64, commentid uint64, status string) error
+	CheckContentHidden(postid uint64, commentid uint64) (bool, error)
+	SetContentHidden(postid uint64, commentid uint64, hidden bool) error
+	AddModerationAction(action ModerationAction) error
+	GetModerationActions(offset uint64, limit uint64) ([]ModerationAction, error)
+	GetUserRole(uid uint64) (string, error)
+	SetUserRole(uid uint64, role string) error
+	GetUserStatus(uid uint64, now time.Time) (UserStatus, error)
+	SetUserStatus(uid uint64, status string, reason string, until time.Time) error
+	GetStats(now time.Time) (Stats, error)
+	CountPosts(uid uint64) (uint64, error)
+	GetUsersByStatus(status string) ([]uint64, error)
+	DeleteUser(uid uint64, now time.Time) ([]Media, []Story, error)
+	AddExport(uid uint64, now time.Time) (Export, error)
+	GetLastExport(uid uint64) (Export, error)
+	GetPendingExports() ([]Export, error)
+	SetExportStatus(exportid uint64, status string, token string, expiration time.Time) error
+	GetExportByToken(token string, now time.Time) (Export, error)
+	GetExpiredExports(now time.Time) ([]Export, error)
+	RemoveExport(exportid uint64) error
+	GetExportData(uid uint64) (ExportData, error)
+	ImportPost(uid uint64, post ImportedPost) (uint64, bool, error)
+	GetUserByOldUsername(username string) (User, error)
+	GetUsernameCollisions() ([]UsernameCollision, error)
+	SetPostPublication(postid uint64, status string, at time.Time) error
+	GetDraftPosts(uid uint64) ([]Post, error)
+	GetScheduledPosts(now time.Time) ([]Post, error)
+	CheckUsernameReleased(username string, skeleton string, uid uint64, since time.Time) (bool, error)
+	CheckRenamedSince(uid uint64, since time.Time) (bool, error)
+	PinPost(uid uint64, postid uint64, max uint64) (bool, error)
+	UnpinPost(uid uint64, postid uint64) error
+	GetPinnedPosts(uid uint64) ([]Post, error)
+	SetPostArchived(postid uint64, archived bool) error
+	GetArchivedPosts(uid uint64) ([]Post, error)
 
 	Ping() error
 }
@@ -81,6 +165,89 @@ type appdbimpl struct {
 	c *sql.DB
 }
 
+// ReactionHeart is the reaction type of a like
+const ReactionHeart = "heart"
+
+// User roles: moderators can review the reports and delete any content, administrators can also manage the users.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// User statuses: a suspended user cannot use the API until the end of the suspension (if any), a deactivated user
+// cannot use the API until he logs in again. A deleted user is a tombstone that keeps the uid, so that what references
+// him renders as a deleted user; his content is removed while he is deleting.
+const (
+	StatusActive      = "active"
+	StatusSuspended   = "suspended"
+	StatusDeactivated = "deactivated"
+	StatusDeleting    = "deleting"
+	StatusDeleted     = "deleted"
+)
+
+// DeletedUsername is the username of the tombstone of a deleted user. The tombstone stores it followed by the uid, as
+// usernames are unique; no valid username contains a space.
+const DeletedUsername = "deleted user"
+
+// Export statuses: an export is pending until the archive is built (ready) or its build fails.
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+)
+
+// Report statuses: a report is open until a moderator resolves it (the content is hidden) or dismisses it.
+const (
+	ReportOpen      = "open"
+	ReportResolved  = "resolved"
+	ReportDismissed = "dismissed"
+)
+
+// Post statuses: a draft is visible only to its owner until he publishes or schedules it; a scheduled post is
+// published by the scheduler at its publish_at time.
+const (
+	PostDraft     = "draft"
+	PostScheduled = "scheduled"
+	PostPublished = "published"
+)
+
+// postPublished is the condition on the post table that selects the published posts. postVisible and commentVisible
+// are the conditions on the post and the comment tables that select the content that can be shown to the users, i.e.
+// the published content not hidden by the moderation (nor archived by its owner).
+const (
+	postPublished  = "post.status = '" + PostPublished + "'"
+	postVisible    = "post.hidden = 0 AND post.archived = 0 AND " + postPublished
+	commentVisible = "comment.hidden = 0"
+)
+
+// inactiveStatuses are the statuses of the users that vanish from the search, the streams and the follower counts:
+// the deactivated users (until they log in again) and the deleted ones. inactiveUsers selects their uid
+// (e.g., "post.uid NOT IN (" + inactiveUsers + ")").
+const (
+	inactiveStatuses = "'" + StatusDeactivated + "', '" + StatusDeleting + "', '" + StatusDeleted + "'"
+	inactiveUsers    = "SELECT inactive.uid FROM user AS inactive WHERE inactive.status IN (" + inactiveStatuses + ")"
+)
+
+const (
+	// userTableStructure is the structure of the user table. The username length is checked in code points, while the
+	// API checks it in user-perceived characters; the skeleton (see UsernameSkeleton) is unique to avoid look-alike
+	// usernames.
+	userTableStructure = "(uid INTEGER PRIMARY KEY, " +
+		"username TEXT NOT NULL CHECK(length(username) <= 80) UNIQUE, " +
+		"skeleton TEXT NOT NULL UNIQUE);"
+
+	// commentTableStructure is the structure of the comment table. The message length is checked in code points, while
+	// the API checks it in user-perceived characters.
+	commentTableStructure = "(commentid INTEGER PRIMARY KEY, " +
+		"message TEXT CHECK(length(message) <= 1024), " +
+		"timestamp DATETIME, " +
+		"postid INTEGER NOT NULL, " +
+		"uid INTEGER NOT NULL, " +
+		"FOREIGN KEY (uid) REFERENCES user(uid), " +
+		"FOREIGN KEY (postid) REFERENCES post(postid))"
+)
+
 // User struct represents a user in every API call between this package and the outside world.
 // Note that the internal representation of user in the database might be different.
 type User struct {
@@ -88,6 +255,13 @@ type User struct {
 	Username string `validate:"min=3, max=20"`
 }
 
+// UsernameCollision struct represents a user whose username is the same of the username of Holder, except for the
+// case. Such users have been created before usernames were case-insensitive.
+type UsernameCollision struct {
+	User   User
+	Holder User
+}
+
 // Comment struct represents a comment in every API call between this package and the outside world.
 // Note that the internal representation of comment in the database might be different.
 type Comment struct {
@@ -96,16 +270,231 @@ type Comment struct {
 	Postid    uint64
 	Message   string `validate:"min=1, max=256"`
 	Datetime  string
+	Mentions  []Mention
+	Reactions map[string]uint64
+	Likes     uint64
+}
+
+// Mention struct represents a @username mention inside a comment in every API call between this package and the
+// outside world. Offset and Length are expressed in code points of the comment message.
+// Note that the internal representation of mention in the database might be different.
+type Mention struct {
+	Uid      uint64
+	Username string
+	Offset   uint64
+	Length   uint64
+}
+
+// Notification struct represents a notification for a user in every API call between this package and the outside
+// world. Actor is the user that caused the notification; Postid and Commentid are 0 when not relevant.
+// Note that the internal representation of notification in the database might be different.
+type Notification struct {
+	Notificationid uint64
+	Uid            uint64
+	Type           string
+	Actor          uint64
+	Postid         uint64
+	Commentid      uint64
+	Datetime       string
 }
 
 // Post struct represents a post in every API call between this package and the outside world.
 // Note that the internal representation of post in the database might be different.
 type Post struct {
+	Postid    uint64
+	Uid       uint64
+	Likes     uint64    `validate:"min=0"`
+	Comments  []Comment `validate:"dive"` // Validate Comments slice element, too
+	Datetime  string    `validate:"datetimeformat"`
+	Caption   string
+	Media     []Media `validate:"dive"`
+	Reactions map[string]uint64
+	Repost    Repost
+	Status    string
+	PublishAt string
+	Archived  bool
+}
+
+// Repost struct represents the repost of a post by a user, with an optional quote, in every API call between this
+// package and the outside world. Owner is the username of the owner of the original post. In a Post, a zero Repostid
+// means that the post is not a repost.
+// Note that the internal representation of repost in the database might be different.
+type Repost struct {
+	Repostid uint64
+	User     User
 	Postid   uint64
-	Uid      uint64
-	Likes    uint64    `validate:"min=0"`
-	Comments []Comment `validate:"dive"` // Validate Comments slice element, too
-	Datetime string    `validate:"datetimeformat"`
+	Owner    string
+	Quote    string
+	Datetime string
+}
+
+// Media struct represents an image of a post in every API call between this package and the outside world.
+// Format is the image file extension ("png" or "jpeg"); Width and Height are in pixels. Format is empty and sizes
+// are 0 if they are not known yet.
+// Note that the internal representation of media in the database might be different.
+type Media struct {
+	Mediaid uint64
+	Postid  uint64
+	Format  string
+	Width   uint64
+	Height  uint64
+}
+
+// PostMatch struct represents a post found by the post search in every API call between this package and the outside
+// world. Commentid is the comment that matches the search (0 if the caption matches), and Snippet is the part of its
+// text that matches, with the matched words delimited by SnippetStart and SnippetEnd.
+type PostMatch struct {
+	Post      Post
+	Commentid uint64
+	Snippet   string
+}
+
+// Tag struct represents a hashtag and the number of posts that use it in every API call between this package and the
+// outside world.
+type Tag struct {
+	Name  string
+	Posts uint64
+}
+
+// Suggestion struct represents a user that could be followed in every API call between this package and the outside
+// world. Mutuals is the number of followed users that follow him, and Via is the username of one of them.
+type Suggestion struct {
+	User    User
+	Mutuals uint64
+	Via     string
+}
+
+// Collection struct represents a named collection of saved posts in every API call between this package and the
+// outside world. Posts is the number of saved posts in the collection that are still visible to its owner.
+// Note that the internal representation of collection in the database might be different.
+type Collection struct {
+	Collectionid uint64
+	Uid          uint64
+	Name         string
+	Posts        uint64
+	Datetime     string
+}
+
+// Story struct represents a story, an image that is visible to the followers of its owner until Expiration, in every
+// API call between this package and the outside world. Seen reports if the user that requested the story has already
+// seen it, and Views is the number of users that have seen it (it's counted only for the owner).
+// Note that the internal representation of story in the database might be different.
+type Story struct {
+	Storyid    uint64
+	Uid        uint64
+	Format     string
+	Width      uint64
+	Height     uint64
+	Datetime   string
+	Expiration string
+	Seen       bool
+	Views      uint64
+}
+
+// StoryView struct represents a user that has seen a story in every API call between this package and the outside
+// world.
+type StoryView struct {
+	User     User
+	Datetime string
+}
+
+// UserStatus struct represents the status of a user account in every API call between this package and the outside
+// world. Reason is the reason of the suspension, and Until its end (empty if the suspension has no end).
+type UserStatus struct {
+	Status string
+	Reason string
+	Until  string
+}
+
+// Stats struct represents the system statistics in every API call between this package and the outside world.
+type Stats struct {
+	Users          uint64
+	SuspendedUsers uint64
+	Posts          uint64
+	HiddenPosts    uint64
+	Comments       uint64
+	HiddenComments uint64
+	Reactions      uint64
+	ActiveStories  uint64
+	OpenReports    uint64
+}
+
+// Report struct represents the report of a post (Commentid is 0) or of a comment in every API call between this package
+// and the outside world. Reports is the number of open reports of the same content.
+// Note that the internal representation of report in the database might be different.
+type Report struct {
+	Reportid  uint64
+	Reporter  User
+	Postid    uint64
+	Commentid uint64
+	Reason    string
+	Status    string
+	Datetime  string
+	Reports   uint64
+}
+
+// ModerationAction struct represents an entry of the moderation audit trail in every API call between this package and
+// the outside world. Actor is 0 when the action is automatic; Uid (the target user), Reportid, Postid and Commentid are
+// 0 when not relevant.
+// Note that the internal representation of moderation action in the database might be different.
+type ModerationAction struct {
+	Actionid  uint64
+	Actor     uint64
+	Action    string
+	Uid       uint64
+	Reportid  uint64
+	Postid    uint64
+	Commentid uint64
+	Detail    string
+	Datetime  string
+}
+
+// Export struct represents an archive with the personal data of a user in every API call between this package and the
+// outside world. Token and Expiration are set when the archive is built (or its build fails).
+// Note that the internal representation of export in the database might be different.
+type Export struct {
+	Exportid   uint64
+	Uid        uint64
+	Status     string
+	Token      string
+	Datetime   string
+	Expiration string
+}
+
+// ExportData struct represents the personal data of a user to export in every API call between this package and the
+// outside world: his profile, all his posts (with their media), his comments and reactions (likes included) on any
+// post, the users that he follows, his followers and the users that he has banned.
+type ExportData struct {
+	Profile   Profile
+	Posts     []Post
+	Comments  []Comment
+	Reactions []UserReaction
+	Following []User
+	Followers []User
+	Banned    []User
+}
+
+// UserReaction struct represents the reaction of a user to a post (Commentid is 0) or to a comment in every API call
+// between this package and the outside world.
+type UserReaction struct {
+	Postid    uint64
+	Commentid uint64
+	Type      string
+	Datetime  string
+}
+
+// ImportedPost struct represents a post imported from an archive in every API call between this package and the
+// outside world. Key identifies the content of the post, Datetime is its original upload time (in the format of the
+// timestamps) and Tags are the hashtags of the caption. Only Format, Width and Height of Media are used.
+// Status is the status of the post, and PublishAt (in the format of the timestamps) is set only for a scheduled post.
+type ImportedPost struct {
+	Key       string
+	Datetime  string
+	Caption   string
+	Tags      []string
+	Media     []Media
+	Status    string
+	PublishAt string
 }
 
 // Profile struct represents a user profile in every API call between this package and the outside world.
@@ -129,20 +518,35 @@ func New(db *sql.DB) (AppDatabase, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating database structure for table user: %w", err)
 	}
+	// check if table UserTrigram exists
+	err = checkTableUserTrigram(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table user_trigram: %w", err)
+	}
 	// check if table Post exists
 	err = checkTablePost(db)
 	if err != nil {
 		return nil, fmt.Errorf("error creating database structure for table post: %w", err)
 	}
+	// check if table PostMedia exists
+	err = checkTablePostMedia(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table post_media: %w", err)
+	}
 	// check if table Comment exists
 	err = checkTableComment(db)
 	if err != nil {
 		return nil, fmt.Errorf("error creating database structure for table comment: %w", err)
 	}
-	// check if table Like exists
-	err = checkTableLike(db)
+	// check if table Reaction exists
+	err = checkTableReaction(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table reaction: %w", err)
+	}
+	// check if view CommentLike exists
+	err = checkViewCommentLike(db)
 	if err != nil {
-		return nil, fmt.Errorf("error creating database structure for table like: %w", err)
+		return nil, fmt.Errorf("error creating database structure for view comment_like: %w", err)
 	}
 	// check if table Follow exists
 	err = checkTableFollow(db)
@@ -154,6 +558,78 @@ func New(db *sql.DB) (AppDatabase, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating database structure for table ban: %w", err)
 	}
+	// check if table PostTag exists
+	err = checkTablePostTag(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table post_tag: %w", err)
+	}
+	// check if table Mention exists
+	err = checkTableMention(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table mention: %w", err)
+	}
+	// check if table Notification exists
+	err = checkTableNotification(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table notification: %w", err)
+	}
+	// check if table Collection exists
+	err = checkTableCollection(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table collection: %w", err)
+	}
+	// check if table SavedPost exists
+	err = checkTableSavedPost(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table saved_post: %w", err)
+	}
+	// check if table Repost exists
+	err = checkTableRepost(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table repost: %w", err)
+	}
+	// check if table Report exists
+	err = checkTableReport(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table report: %w", err)
+	}
+
+	// check if table ModerationAction exists
+	err = checkTableModerationAction(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table moderation_action: %w", err)
+	}
+
+	// check if table PostSearch exists
+	err = checkTablePostSearch(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table post_search: %w", err)
+	}
+	// check if table Story exists
+	err = checkTableStory(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table story: %w", err)
+	}
+	// check if table StoryView exists
+	err = checkTableStoryView(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table story_view: %w", err)
+	}
+	// check if table Export exists
+	err = checkTableExport(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table export: %w", err)
+	}
+	// check if table PostImport exists
+	err = checkTablePostImport(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table post_import: %w", err)
+	}
+	// check if table UsernameHistory exists
+	err = checkTableUsernameHistory(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database structure for table username_history: %w", err)
+	}
 
 	return &appdbimpl{
 		c: db,
@@ -171,17 +647,186 @@ func checkTableUser(db *sql.DB) error {
 	var tableName string
 	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='user';`).Scan(&tableName)
 	if errors.Is(err, sql.ErrNoRows) {
-		sqlStmt := "CREATE TABLE user " +
-			"(uid INTEGER PRIMARY KEY, " +
-			"username TEXT NOT NULL CHECK(length(username) <= 20) UNIQUE);"
+		sqlStmt := "CREATE TABLE user " + userTableStructure
 		_, err = db.Exec(sqlStmt)
 		if err != nil {
 			return fmt.Errorf("error creating database structure: %w", err)
 		}
+	} else {
+		// Usernames created before Unicode support were limited to 20 ASCII characters and had no skeleton
+		err = migrateTable(db, "user", "length(username) <= 20", userTableStructure, "uid, username, username")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Users created before roles and suspensions are active users
+	columns := [][2]string{
+		{"role", "TEXT NOT NULL DEFAULT '" + RoleUser + "'"},
+		{"status", "TEXT NOT NULL DEFAULT '" + StatusActive + "'"},
+		{"status_reason", "TEXT NOT NULL DEFAULT ''"},
+		{"status_until", "DATETIME"},
+		{"username_key", "TEXT"},
+	}
+	for _, column := range columns {
+		err = checkColumn(db, "user", column[0], column[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = checkUsernameKeys(db)
+	if err != nil {
+		return err
+	}
+	return checkUsernameSkeletons(db)
+}
+
+/*
+ * checkUsernameKeys computes the key of the usernames created before usernames were case-insensitive, and it makes the
+ * keys unique. When more users have the same username except for the case, only the oldest one gets the key: the
+ * other ones keep a NULL key (they can still log in with their exact username) and are returned by
+ * GetUsernameCollisions until they are renamed.
+ */
+func checkUsernameKeys(db *sql.DB) error {
+	rows, err := db.Query("SELECT uid, username FROM user WHERE username_key IS NULL ORDER BY uid")
+	if err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	var users []User
+	for rows.Next() {
+		var user User
+		if err = rows.Scan(&user.Userid, &user.Username); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("error reading usernames: %w", err)
+		}
+		users = append(users, user)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	for _, user := range users {
+		// the key is set only if no other user has it yet, so that the oldest user gets it
+		_, err = db.Exec("UPDATE user SET username_key = ? WHERE uid = ? AND NOT EXISTS "+
+			"(SELECT 1 FROM user AS other WHERE other.username_key = ?)", usernameKey(user.Username), user.Userid,
+			usernameKey(user.Username))
+		if err != nil {
+			return fmt.Errorf("error migrating usernames: %w", err)
+		}
+	}
+
+	_, err = db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS user_username_key ON user (username_key)")
+	if err != nil {
+		return fmt.Errorf("error creating database structure: %w", err)
 	}
 	return nil
 }
 
+/*
+ * checkUsernameSkeletons recomputes the skeletons that differ from UsernameSkeleton: the ones copied from the username
+ * when the table has been migrated, and the ones computed before skeletons were case-insensitive. A skeleton is
+ * changed only if no other user has it yet, so that the oldest user gets it: users that were already confusable keep
+ * their old skeleton.
+ */
+func checkUsernameSkeletons(db *sql.DB) error {
+	rows, err := db.Query("SELECT uid, username, skeleton FROM user ORDER BY uid")
+	if err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	var users []User
+	for rows.Next() {
+		var user User
+		var skeleton string
+		if err = rows.Scan(&user.Userid, &user.Username, &skeleton); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("error reading usernames: %w", err)
+		}
+		if UsernameSkeleton(user.Username) != skeleton {
+			users = append(users, user)
+		}
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	for _, user := range users {
+		skeleton := UsernameSkeleton(user.Username)
+		_, err = db.Exec("UPDATE user SET skeleton = ? WHERE uid = ? AND NOT EXISTS "+
+			"(SELECT 1 FROM user AS other WHERE other.skeleton = ?)", skeleton, user.Userid, skeleton)
+		if err != nil {
+			return fmt.Errorf("error migrating usernames: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableUserTrigram check if UserTrigram table already exists. If not exists, it will create that and it will index
+ * the usernames of the existing users.
+ * Each row links a trigram to the user whose username contains it, and it's used for the fuzzy search of users.
+ */
+func checkTableUserTrigram(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='user_trigram';`).Scan(&tableName)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting migration: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	sqlStmt := "CREATE TABLE user_trigram " +
+		"(trigram TEXT NOT NULL, " +
+		"uid INTEGER NOT NULL, " +
+		"PRIMARY KEY (trigram, uid), " +
+		"FOREIGN KEY (uid) REFERENCES user(uid))"
+	_, err = tx.Exec(sqlStmt)
+	if err != nil {
+		return fmt.Errorf("error creating database structure: %w", err)
+	}
+	_, err = tx.Exec("CREATE INDEX user_trigram_uid ON user_trigram (uid)")
+	if err != nil {
+		return fmt.Errorf("error creating database structure: %w", err)
+	}
+
+	// Index the users created before the search index
+	rows, err := tx.Query("SELECT uid, username FROM user")
+	if err != nil {
+		return fmt.Errorf("error reading users: %w", err)
+	}
+	var users []User
+	for rows.Next() {
+		var user User
+		if err = rows.Scan(&user.Userid, &user.Username); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("error reading users: %w", err)
+		}
+		users = append(users, user)
+	}
+	_ = rows.Close()
+	if rows.Err() != nil {
+		return fmt.Errorf("error reading users: %w", rows.Err())
+	}
+
+	for _, user := range users {
+		if err = indexUsername(tx, user.Userid, user.Username); err != nil {
+			return fmt.Errorf("error indexing username: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 /*
  * checkTablePost check if Post table already exists. If not exists, it will create that.
  */
@@ -199,6 +844,64 @@ func checkTablePost(db *sql.DB) error {
 			return fmt.Errorf("error creating database structure: %w", err)
 		}
 	}
+	err = checkColumn(db, "post", "caption", "TEXT NOT NULL DEFAULT ''")
+	if err != nil {
+		return err
+	}
+
+	// Posts hidden by the moderation (or archived by their owner) are not shown to the users, posts created before
+	// drafts are published and pinned is NULL for the posts not pinned to the profile (it grows with each post pinned
+	// by the user)
+	columns := [][2]string{
+		{"hidden", "INTEGER NOT NULL DEFAULT 0"},
+		{"archived", "INTEGER NOT NULL DEFAULT 0"},
+		{"status", "TEXT NOT NULL DEFAULT '" + PostPublished + "'"},
+		{"publish_at", "DATETIME"},
+		{"pinned", "INTEGER"},
+	}
+	for _, column := range columns {
+		err = checkColumn(db, "post", column[0], column[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS post_status ON post (status, publish_at)")
+	if err != nil {
+		return fmt.Errorf("error creating database structure: %w", err)
+	}
+	return nil
+}
+
+/*
+ * checkTablePostMedia check if PostMedia table already exists. If not exists, it will create that.
+ * Each row is an image of a post, in the order of the post carousel. Posts created before carousel support have a
+ * single image whose file is named after the postid, so their media are created with mediaid = postid and an unknown
+ * format and size.
+ */
+func checkTablePostMedia(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='post_media';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		statements := []string{
+			"CREATE TABLE post_media " +
+				"(mediaid INTEGER PRIMARY KEY, " +
+				"postid INTEGER NOT NULL, " +
+				"position INTEGER NOT NULL, " +
+				"format TEXT NOT NULL DEFAULT '', " +
+				"width INTEGER NOT NULL DEFAULT 0, " +
+				"height INTEGER NOT NULL DEFAULT 0, " +
+				"UNIQUE (postid, position), " +
+				"FOREIGN KEY (postid) REFERENCES post(postid))",
+			"INSERT INTO post_media (mediaid, postid, position) SELECT postid, postid, 0 FROM post",
+		}
+		for _, sqlStmt := range statements {
+			_, err = db.Exec(sqlStmt)
+			if err != nil {
+				return fmt.Errorf("error creating database structure: %w", err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -209,39 +912,94 @@ func checkTableComment(db *sql.DB) error {
 	var tableName string
 	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='comment';`).Scan(&tableName)
 	if errors.Is(err, sql.ErrNoRows) {
-		sqlStmt := "CREATE TABLE comment " +
-			"(commentid INTEGER PRIMARY KEY, " +
-			"message TEXT CHECK(length(message) <= 265), " +
-			"timestamp DATETIME, " +
-			"postid INTEGER NOT NULL, " +
-			"uid INTEGER NOT NULL, " +
-			"FOREIGN KEY (uid) REFERENCES user(uid), " +
-			"FOREIGN KEY (postid) REFERENCES post(postid))"
+		sqlStmt := "CREATE TABLE comment " + commentTableStructure
 		_, err = db.Exec(sqlStmt)
 		if err != nil {
 			return fmt.Errorf("error creating database structure: %w", err)
 		}
+	} else {
+		// Messages created before Unicode support were limited to 265 code points
+		err = migrateTable(db, "comment", "length(message) <= 265", commentTableStructure,
+			"commentid, message, timestamp, postid, uid")
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+
+	// Comments hidden by the moderation are not shown to the users
+	return checkColumn(db, "comment", "hidden", "INTEGER NOT NULL DEFAULT 0")
 }
 
 /*
- * checkTableLike check if Like table already exists. If not exists, it will create that.
+ * checkTableReaction check if Reaction table already exists. If not exists, it will create that.
+ * Each row is the reaction of a user to a post (commentid = 0) or to one of its comments; a user has at most one
+ * reaction for each of them. The likes of the old like table are converted to ReactionHeart reactions.
  */
-func checkTableLike(db *sql.DB) error {
+func checkTableReaction(db *sql.DB) error {
 	var tableName string
-	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='like';`).Scan(&tableName)
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='reaction';`).Scan(&tableName)
 	if errors.Is(err, sql.ErrNoRows) {
-		sqlStmt := "CREATE TABLE like " +
+		sqlStmt := "CREATE TABLE reaction " +
 			"(uid INTEGER NOT NULL, " +
 			"postid INTEGER NOT NULL, " +
-			"PRIMARY KEY (uid, postid), " +
+			"commentid INTEGER NOT NULL DEFAULT 0, " +
+			"type TEXT NOT NULL, " +
+			"timestamp DATETIME, " +
+			"PRIMARY KEY (uid, postid, commentid), " +
 			"FOREIGN KEY (uid) REFERENCES user(uid), " +
 			"FOREIGN KEY (postid) REFERENCES post(postid))"
 		_, err = db.Exec(sqlStmt)
 		if err != nil {
 			return fmt.Errorf("error creating database structure: %w", err)
 		}
+		_, err = db.Exec("CREATE INDEX reaction_postid ON reaction (postid, commentid)")
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+
+	err = db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='like';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error migrating likes: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error migrating likes: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec("INSERT OR IGNORE INTO reaction (uid, postid, commentid, type) SELECT uid, postid, 0, ? FROM like", ReactionHeart)
+	if err != nil {
+		return fmt.Errorf("error migrating likes: %w", err)
+	}
+	_, err = tx.Exec("DROP TABLE like")
+	if err != nil {
+		return fmt.Errorf("error migrating likes: %w", err)
+	}
+	return tx.Commit()
+}
+
+/*
+ * checkViewCommentLike check if CommentLike view already exists. If not exists, it will create that.
+ * A like to a comment is its ReactionHeart reaction, so the view selects them from the reaction table.
+ */
+func checkViewCommentLike(db *sql.DB) error {
+	var viewName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='view' AND name='comment_like';`).Scan(&viewName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE VIEW comment_like AS " +
+			"SELECT uid, postid, commentid, timestamp FROM reaction " +
+			"WHERE commentid != 0 AND type = '" + ReactionHeart + "'"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
 	}
 	return nil
 }
@@ -287,3 +1045,498 @@ func checkTableBan(db *sql.DB) error {
 	}
 	return nil
 }
+
+/*
+ * checkTablePostTag check if PostTag table already exists. If not exists, it will create that.
+ * Each row links a hashtag to the post whose caption (commentid = 0) or comment contains it.
+ */
+func checkTablePostTag(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='post_tag';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE post_tag " +
+			"(postid INTEGER NOT NULL, " +
+			"commentid INTEGER NOT NULL DEFAULT 0, " +
+			"tag TEXT NOT NULL, " +
+			"timestamp DATETIME, " +
+			"PRIMARY KEY (postid, commentid, tag), " +
+			"FOREIGN KEY (postid) REFERENCES post(postid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+		_, err = db.Exec("CREATE INDEX post_tag_tag ON post_tag (tag, timestamp)")
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableMention check if Mention table already exists. If not exists, it will create that.
+ * Mentions reference the user by uid, so they survive username changes.
+ */
+func checkTableMention(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='mention';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE mention " +
+			"(commentid INTEGER NOT NULL, " +
+			"uid INTEGER NOT NULL, " +
+			"position INTEGER NOT NULL, " +
+			"length INTEGER NOT NULL, " +
+			"PRIMARY KEY (commentid, position), " +
+			"FOREIGN KEY (commentid) REFERENCES comment(commentid), " +
+			"FOREIGN KEY (uid) REFERENCES user(uid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableNotification check if Notification table already exists. If not exists, it will create that.
+ */
+func checkTableNotification(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='notification';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE notification " +
+			"(notificationid INTEGER PRIMARY KEY, " +
+			"uid INTEGER NOT NULL, " +
+			"type TEXT NOT NULL, " +
+			"actor INTEGER NOT NULL, " +
+			"postid INTEGER NOT NULL DEFAULT 0, " +
+			"commentid INTEGER NOT NULL DEFAULT 0, " +
+			"timestamp DATETIME, " +
+			"FOREIGN KEY (uid) REFERENCES user(uid), " +
+			"FOREIGN KEY (actor) REFERENCES user(uid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableCollection check if Collection table already exists. If not exists, it will create that.
+ * Collection names are unique for each user.
+ */
+func checkTableCollection(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='collection';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE collection " +
+			"(collectionid INTEGER PRIMARY KEY, " +
+			"uid INTEGER NOT NULL, " +
+			"name TEXT NOT NULL CHECK(length(name) <= 128), " +
+			"timestamp DATETIME, " +
+			"UNIQUE (uid, name), " +
+			"FOREIGN KEY (uid) REFERENCES user(uid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableSavedPost check if SavedPost table already exists. If not exists, it will create that.
+ * Each row is a post saved by a user, optionally in one of his collections (collectionid = 0 if not).
+ */
+func checkTableSavedPost(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='saved_post';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE saved_post " +
+			"(uid INTEGER NOT NULL, " +
+			"postid INTEGER NOT NULL, " +
+			"collectionid INTEGER NOT NULL DEFAULT 0, " +
+			"timestamp DATETIME, " +
+			"PRIMARY KEY (uid, postid), " +
+			"FOREIGN KEY (uid) REFERENCES user(uid), " +
+			"FOREIGN KEY (postid) REFERENCES post(postid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableRepost check if Repost table already exists. If not exists, it will create that.
+ * Each row is a post reposted by a user, with an optional quote; a user can repost a post only once.
+ */
+func checkTableRepost(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='repost';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE repost " +
+			"(repostid INTEGER PRIMARY KEY, " +
+			"uid INTEGER NOT NULL, " +
+			"postid INTEGER NOT NULL, " +
+			"quote TEXT NOT NULL DEFAULT '' CHECK(length(quote) <= 1024), " +
+			"timestamp DATETIME, " +
+			"UNIQUE (uid, postid), " +
+			"FOREIGN KEY (uid) REFERENCES user(uid), " +
+			"FOREIGN KEY (postid) REFERENCES post(postid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableReport check if Report table already exists. If not exists, it will create that.
+ * Each row is the report of a post (commentid is 0) or of a comment by a user, that can report a content only once.
+ */
+func checkTableReport(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='report';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		statements := []string{
+			"CREATE TABLE report " +
+				"(reportid INTEGER PRIMARY KEY, " +
+				"uid INTEGER NOT NULL, " +
+				"postid INTEGER NOT NULL, " +
+				"commentid INTEGER NOT NULL DEFAULT 0, " +
+				"reason TEXT NOT NULL, " +
+				"status TEXT NOT NULL DEFAULT '" + ReportOpen + "', " +
+				"timestamp DATETIME, " +
+				"UNIQUE (uid, postid, commentid), " +
+				"FOREIGN KEY (uid) REFERENCES user(uid))",
+			"CREATE INDEX report_status ON report (status, postid, commentid)",
+		}
+		for _, sqlStmt := range statements {
+			_, err = db.Exec(sqlStmt)
+			if err != nil {
+				return fmt.Errorf("error creating database structure: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableModerationAction check if ModerationAction table already exists. If not exists, it will create that.
+ * It's the audit trail of the moderation: rows are never updated or deleted.
+ */
+func checkTableModerationAction(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='moderation_action';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE moderation_action " +
+			"(actionid INTEGER PRIMARY KEY, " +
+			"actor INTEGER NOT NULL DEFAULT 0, " +
+			"action TEXT NOT NULL, " +
+			"reportid INTEGER NOT NULL DEFAULT 0, " +
+			"postid INTEGER NOT NULL DEFAULT 0, " +
+			"commentid INTEGER NOT NULL DEFAULT 0, " +
+			"detail TEXT NOT NULL DEFAULT '', " +
+			"timestamp DATETIME)"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+
+	// uid is the user target of the action (e.g., the suspended user), 0 when not relevant
+	return checkColumn(db, "moderation_action", "uid", "INTEGER NOT NULL DEFAULT 0")
+}
+
+/*
+ * checkTablePostSearch check if PostSearch full-text index already exists. If not exists, it will create that and it
+ * will index the captions and comments already present.
+ * Each row contains the text of a post caption (commentid = 0) or of a comment; see postSearchDocid for the row ids.
+ */
+func checkTablePostSearch(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='post_search';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		statements := []string{
+			"CREATE VIRTUAL TABLE post_search USING fts4 " +
+				"(postid, commentid, text, notindexed=postid, notindexed=commentid, " +
+				"tokenize=unicode61 \"remove_diacritics=2\")",
+			"INSERT INTO post_search (docid, postid, commentid, text) " +
+				"SELECT -postid, postid, 0, caption FROM post WHERE caption != ''",
+			"INSERT INTO post_search (docid, postid, commentid, text) " +
+				"SELECT commentid, postid, commentid, message FROM comment",
+		}
+		for _, sqlStmt := range statements {
+			_, err = db.Exec(sqlStmt)
+			if err != nil {
+				return fmt.Errorf("error creating database structure: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ * checkColumn check if the column already exists in the table. If not exists, it will add that with the specified
+ * definition.
+ */
+func checkColumn(db *sql.DB, table string, column string, definition string) error {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?;`, table, column).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("error reading database structure: %w", err)
+	}
+	if count == 0 {
+		_, err = db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * migrateTable rebuilds the table `name` with the new `structure` if its current definition contains `outdated`.
+ * SQLite doesn't allow changing constraints with ALTER TABLE, so the rows are copied (using the `columns` select list)
+ * in a new table that replaces the old one.
+ */
+func migrateTable(db *sql.DB, name string, outdated string, structure string, columns string) error {
+	var tableSQL string
+	err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type='table' AND name=?;`, name).Scan(&tableSQL)
+	if err != nil {
+		return fmt.Errorf("error reading database structure: %w", err)
+	}
+	if !strings.Contains(tableSQL, outdated) {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting migration: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	newName := name + "_new"
+	statements := []string{
+		"CREATE TABLE " + newName + " " + structure,
+		"INSERT INTO " + newName + " SELECT " + columns + " FROM " + name,
+		"DROP TABLE " + name,
+		"ALTER TABLE " + newName + " RENAME TO " + name,
+	}
+	for _, sqlStmt := range statements {
+		if _, err = tx.Exec(sqlStmt); err != nil {
+			return fmt.Errorf("error migrating database structure: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+/*
+ * checkTableStory check if Story table already exists. If not exists, it will create that.
+ * Each row is a story image, stored in media/stories, that is visible until its expiration.
+ */
+func checkTableStory(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='story';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE story " +
+			"(storyid INTEGER PRIMARY KEY, " +
+			"uid INTEGER NOT NULL, " +
+			"format TEXT NOT NULL, " +
+			"width INTEGER NOT NULL DEFAULT 0, " +
+			"height INTEGER NOT NULL DEFAULT 0, " +
+			"timestamp DATETIME NOT NULL, " +
+			"expiration DATETIME NOT NULL, " +
+			"FOREIGN KEY (uid) REFERENCES user(uid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+		_, err = db.Exec("CREATE INDEX story_uid_expiration ON story (uid, expiration)")
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableStoryView check if StoryView table already exists. If not exists, it will create that.
+ * Each row is a story seen by a user.
+ */
+func checkTableStoryView(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='story_view';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE story_view " +
+			"(storyid INTEGER NOT NULL, " +
+			"uid INTEGER NOT NULL, " +
+			"timestamp DATETIME NOT NULL, " +
+			"PRIMARY KEY (storyid, uid), " +
+			"FOREIGN KEY (storyid) REFERENCES story(storyid), " +
+			"FOREIGN KEY (uid) REFERENCES user(uid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableExport check if Export table already exists. If not exists, it will create that.
+ * Each row is an archive with the personal data of a user, built in background. Token is the secret of the download
+ * link, valid until expiration.
+ */
+func checkTableExport(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='export';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE export " +
+			"(exportid INTEGER PRIMARY KEY, " +
+			"uid INTEGER NOT NULL, " +
+			"status TEXT NOT NULL DEFAULT '" + ExportPending + "', " +
+			"token TEXT NOT NULL DEFAULT '', " +
+			"timestamp DATETIME NOT NULL, " +
+			"expiration DATETIME, " +
+			"FOREIGN KEY (uid) REFERENCES user(uid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+		_, err = db.Exec("CREATE INDEX export_uid ON export (uid, exportid)")
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTablePostImport check if PostImport table already exists. If not exists, it will create that.
+ * Each row links a post imported from an archive to the key of its content, so that an import can be run again
+ * without duplicating the posts.
+ */
+func checkTablePostImport(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='post_import';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		sqlStmt := "CREATE TABLE post_import " +
+			"(uid INTEGER NOT NULL, " +
+			"importkey TEXT NOT NULL, " +
+			"postid INTEGER NOT NULL, " +
+			"PRIMARY KEY (uid, importkey), " +
+			"FOREIGN KEY (uid) REFERENCES user(uid), " +
+			"FOREIGN KEY (postid) REFERENCES post(postid))"
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return fmt.Errorf("error creating database structure: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+ * checkTableUsernameHistory check if UsernameHistory table already exists. If not exists, it will create that.
+ * Each row is a username (with its skeleton) left by a user, and the time it has been released.
+ */
+func checkTableUsernameHistory(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type='table' AND name='username_history';`).Scan(&tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		statements := []string{
+			"CREATE TABLE username_history " +
+				"(uid INTEGER NOT NULL, " +
+				"username TEXT NOT NULL, " +
+				"skeleton TEXT NOT NULL, " +
+				"username_key TEXT, " +
+				"released DATETIME NOT NULL, " +
+				"FOREIGN KEY (uid) REFERENCES user(uid))",
+			"CREATE INDEX username_history_skeleton ON username_history (skeleton, released)",
+			"CREATE INDEX username_history_uid ON username_history (uid, released)",
+		}
+		for _, sqlStmt := range statements {
+			_, err = db.Exec(sqlStmt)
+			if err != nil {
+				return fmt.Errorf("error creating database structure: %w", err)
+			}
+		}
+	}
+
+	// Usernames left before usernames were case-insensitive have no key
+	err = checkColumn(db, "username_history", "username_key", "TEXT")
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query("SELECT DISTINCT username FROM username_history WHERE username_key IS NULL")
+	if err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	var usernames []string
+	for rows.Next() {
+		var username string
+		if err = rows.Scan(&username); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("error reading usernames: %w", err)
+		}
+		usernames = append(usernames, username)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	for _, username := range usernames {
+		_, err = db.Exec("UPDATE username_history SET username_key = ? WHERE username = ?", usernameKey(username),
+			username)
+		if err != nil {
+			return fmt.Errorf("error migrating usernames: %w", err)
+		}
+	}
+
+	// Skeletons computed before skeletons were case-insensitive are recomputed
+	rows, err = db.Query("SELECT DISTINCT username, skeleton FROM username_history")
+	if err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	usernames = nil
+	for rows.Next() {
+		var username, skeleton string
+		if err = rows.Scan(&username, &skeleton); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("error reading usernames: %w", err)
+		}
+		if UsernameSkeleton(username) != skeleton {
+			usernames = append(usernames, username)
+		}
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error reading usernames: %w", err)
+	}
+
+	for _, username := range usernames {
+		_, err = db.Exec("UPDATE username_history SET skeleton = ? WHERE username = ?", UsernameSkeleton(username),
+			username)
+		if err != nil {
+			return fmt.Errorf("error migrating usernames: %w", err)
+		}
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS username_history_username_key ON username_history " +
+		"(username_key, released)")
+	if err != nil {
+		return fmt.Errorf("error creating database structure: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package database
+
+// DeleteCollection allows to delete a collection of saved posts. The posts in the collection stay saved, without a
+// collection.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) DeleteCollection(collectionid uint64) error {
+	tx, err := db.c.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec("UPDATE saved_post SET collectionid = 0 WHERE collectionid = ?", collectionid)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM collection WHERE collectionid = ?", collectionid)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
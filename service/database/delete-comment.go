@@ -1,6 +1,33 @@
 package database
 
+// DeleteComment allows to delete a comment, together with its hashtags, mentions, reactions (likes included),
+// notifications, reports and search index entry. Reports are removed because comment ids can be reused.
+// Function will return nil if no errors are present, an error otherwise.
 func (db *appdbimpl) DeleteComment(commentid uint64) error {
-	_, err := db.c.Exec("DELETE FROM comment WHERE commentid = ?", commentid)
+	_, err := db.c.Exec("DELETE FROM post_tag WHERE commentid = ?", commentid)
+	if err != nil {
+		return err
+	}
+	_, err = db.c.Exec("DELETE FROM mention WHERE commentid = ?", commentid)
+	if err != nil {
+		return err
+	}
+	_, err = db.c.Exec("DELETE FROM reaction WHERE commentid = ?", commentid)
+	if err != nil {
+		return err
+	}
+	_, err = db.c.Exec("DELETE FROM notification WHERE commentid = ?", commentid)
+	if err != nil {
+		return err
+	}
+	_, err = db.c.Exec("DELETE FROM report WHERE commentid = ?", commentid)
+	if err != nil {
+		return err
+	}
+	_, err = db.c.Exec("DELETE FROM post_search WHERE docid = ?", postSearchDocid(0, commentid))
+	if err != nil {
+		return err
+	}
+	_, err = db.c.Exec("DELETE FROM comment WHERE commentid = ?", commentid)
 	return err
 }
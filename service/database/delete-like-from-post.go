@@ -1,8 +1,9 @@
 package database
 
-// UnlikePost allows to delete a user like to a specified post.
+// UnlikePost allows to remove the like (the ReactionHeart reaction) of uid from a specified post. Other reactions
+// are not removed.
 // Function will return nil if no errors are present, an error otherwise.
 func (db *appdbimpl) UnlikePost(postid uint64, userid uint64) error {
-	_, err := db.c.Exec("DELETE FROM like WHERE postid = ? AND uid = ?", postid, userid)
+	_, err := db.c.Exec("DELETE FROM reaction WHERE postid = ? AND commentid = 0 AND uid = ? AND type = ?", postid, userid, ReactionHeart)
 	return err
 }
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeleteUser allows to delete a user in a single transaction: his posts (with their comments, hashtags, reactions,
+// saves, reposts and media), his comments and reactions on the other posts, his follows, bans, collections, saves,
+// reposts, notifications and stories are removed, his exports expire, and the user row becomes a tombstone with
+// StatusDeleted. The username is added to the username history, released at now, so that it is subject to the same
+// cooldown of a changed username; the old usernames are kept for the same reason.
+// The reports on his content are removed, as the ids of posts and comments can be reused; mentions, reports filed by
+// him and moderation actions referencing him are kept, and render as a deleted user.
+// The media of the posts and the stories are returned, so that their images can be removed.
+func (db *appdbimpl) DeleteUser(uid uint64, now time.Time) ([]Media, []Story, error) {
+	const (
+		userPosts    = "SELECT postid FROM post WHERE uid = ?"
+		userComments = "SELECT commentid FROM comment WHERE uid = ? OR postid IN (" + userPosts + ")"
+	)
+
+	// The statements are executed in order, as the later ones remove the rows selected by the former ones.
+	// Each placeholder is the uid.
+	statements := []string{
+		// Comments of the user and comments under his posts
+		"DELETE FROM post_tag WHERE commentid IN (" + userComments + ")",
+		"DELETE FROM mention WHERE commentid IN (" + userComments + ")",
+		"DELETE FROM reaction WHERE commentid IN (" + userComments + ")",
+		"DELETE FROM notification WHERE commentid IN (" + userComments + ")",
+		"DELETE FROM report WHERE commentid IN (" + userComments + ")",
+		"DELETE FROM post_search WHERE docid IN (" + userComments + ")",
+		"DELETE FROM comment WHERE commentid IN (" + userComments + ")",
+
+		// Posts of the user
+		"DELETE FROM post_tag WHERE postid IN (" + userPosts + ")",
+		"DELETE FROM reaction WHERE postid IN (" + userPosts + ")",
+		"DELETE FROM notification WHERE postid IN (" + userPosts + ")",
+		"DELETE FROM report WHERE postid IN (" + userPosts + ")",
+		"DELETE FROM post_search WHERE docid IN (SELECT -postid FROM post WHERE uid = ?)",
+		"DELETE FROM saved_post WHERE postid IN (" + userPosts + ")",
+		"DELETE FROM repost WHERE postid IN (" + userPosts + ")",
+		"DELETE FROM post_media WHERE postid IN (" + userPosts + ")",
+		"DELETE FROM post_import WHERE uid = ?",
+		"DELETE FROM post WHERE uid = ?",
+
+		// Everything else of the user
+		"DELETE FROM reaction WHERE uid = ?",
+		"DELETE FROM follow WHERE uid = ? OR fuid = ?",
+		"DELETE FROM ban WHERE uid = ? OR buid = ?",
+		"DELETE FROM saved_post WHERE uid = ?",
+		"DELETE FROM collection WHERE uid = ?",
+		"DELETE FROM repost WHERE uid = ?",
+		"DELETE FROM notification WHERE uid = ? OR actor = ?",
+		"DELETE FROM story_view WHERE uid = ? OR storyid IN (SELECT storyid FROM story WHERE uid = ?)",
+		"DELETE FROM story WHERE uid = ?",
+		"DELETE FROM user_trigram WHERE uid = ?",
+
+		// Exports expire at once, so that their archives are removed and can't be downloaded anymore
+		"UPDATE export SET status = '" + ExportFailed + "', expiration = timestamp WHERE uid = ?",
+	}
+
+	tx, err := db.c.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	media, err := userMedia(tx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stories, err := userStories(tx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, statement := range statements {
+		args := make([]interface{}, strings.Count(statement, "?"))
+		for i := range args {
+			args[i] = uid
+		}
+
+		_, err = tx.Exec(statement, args...)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var username, skeleton string
+	err = tx.QueryRow("SELECT username, skeleton FROM user WHERE uid = ?", uid).Scan(&username, &skeleton)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = tx.Exec("INSERT INTO username_history (uid, username, skeleton, username_key, released) "+
+		"VALUES (?, ?, ?, ?, ?)", uid, username, skeleton, usernameKey(username), databaseTime(now))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The tombstone frees the username, which is unique
+	tombstone := DeletedUsername + " " + strconv.FormatUint(uid, 10)
+	_, err = tx.Exec("UPDATE user SET username = ?, skeleton = ?, username_key = ?, role = ?, status = ?, "+
+		"status_reason = '', status_until = NULL WHERE uid = ?", tombstone, tombstone, usernameKey(tombstone), RoleUser,
+		StatusDeleted, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return media, stories, tx.Commit()
+}
+
+// userMedia returns the media of all the posts of a user.
+func userMedia(tx *sql.Tx, uid uint64) ([]Media, error) {
+	rows, err := tx.Query("SELECT post_media.mediaid, post_media.postid FROM post_media "+
+		"JOIN post ON post.postid = post_media.postid WHERE post.uid = ?", uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var media []Media
+	for rows.Next() {
+		var item Media
+		if err = rows.Scan(&item.Mediaid, &item.Postid); err != nil {
+			return media, err
+		}
+		media = append(media, item)
+	}
+
+	return media, rows.Err()
+}
+
+// userStories returns all the stories of a user, expired ones included.
+func userStories(tx *sql.Tx, uid uint64) ([]Story, error) {
+	rows, err := tx.Query("SELECT storyid, uid, format FROM story WHERE uid = ?", uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var stories []Story
+	for rows.Next() {
+		var story Story
+		if err = rows.Scan(&story.Storyid, &story.Uid, &story.Format); err != nil {
+			return stories, err
+		}
+		stories = append(stories, story)
+	}
+
+	return stories, rows.Err()
+}
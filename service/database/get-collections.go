@@ -0,0 +1,51 @@
+package database
+
+import (
+	"database/sql"
+)
+
+const (
+	// collectionsQuery selects the collections with the number of their saved posts still visible to the owner
+	collectionsQuery = "SELECT collection.collectionid, collection.uid, collection.name, collection.timestamp, " +
+		"(SELECT COUNT(*) FROM saved_post JOIN post ON post.postid = saved_post.postid " +
+		"WHERE saved_post.uid = collection.uid AND saved_post.collectionid = collection.collectionid AND " + postVisible + " " +
+		"AND post.uid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = collection.uid) " +
+		"AND post.uid NOT IN (SELECT ban.buid FROM ban WHERE ban.uid = collection.uid)) " +
+		"FROM collection "
+)
+
+// GetCollection allows to get a collection of saved posts.
+// Request will fail if collectionid doesn't exist.
+func (db *appdbimpl) GetCollection(collectionid uint64) (Collection, error) {
+	var collection Collection
+	err := db.c.QueryRow(collectionsQuery+"WHERE collection.collectionid = ?", collectionid).Scan(&collection.Collectionid,
+		&collection.Uid, &collection.Name, &collection.Datetime, &collection.Posts)
+	return collection, err
+}
+
+// GetCollections allows to get all the collections of saved posts of a user, ordered by name.
+func (db *appdbimpl) GetCollections(uid uint64) ([]Collection, error) {
+	rows, err := db.c.Query(collectionsQuery+"WHERE collection.uid = ? ORDER BY collection.name", uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var collections []Collection
+	for rows.Next() {
+		var collection Collection
+		err = rows.Scan(&collection.Collectionid, &collection.Uid, &collection.Name, &collection.Datetime, &collection.Posts)
+		if err != nil {
+			return collections, err
+		}
+		collections = append(collections, collection)
+	}
+
+	if rows.Err() != nil {
+		return collections, rows.Err()
+	}
+
+	return collections, nil
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetCommentMentions allows to get all the mentions inside a comment, ordered by their position in the message.
+// The username of each mention is the current one of the mentioned user.
+func (db *appdbimpl) GetCommentMentions(commentid uint64) ([]Mention, error) {
+	const (
+		mentionsQuery = "SELECT mention.uid, user.username, mention.position, mention.length FROM mention " +
+			"JOIN user ON user.uid = mention.uid WHERE mention.commentid = ? ORDER BY mention.position"
+	)
+
+	rows, err := db.c.Query(mentionsQuery, commentid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var mentions []Mention
+	for rows.Next() {
+		var mention Mention
+		err = rows.Scan(&mention.Uid, &mention.Username, &mention.Offset, &mention.Length)
+		if err != nil {
+			return mentions, err
+		}
+		mentions = append(mentions, mention)
+	}
+
+	if rows.Err() != nil {
+		return mentions, rows.Err()
+	}
+
+	return mentions, nil
+}
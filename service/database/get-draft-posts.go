@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetDraftPosts allows to get the unpublished posts of a user (drafts and scheduled posts), in reverse chronological
+// order of upload.
+func (db *appdbimpl) GetDraftPosts(uid uint64) ([]Post, error) {
+	const (
+		postsQuery = "SELECT post.postid, post.uid, post.timestamp, post.caption, post.status, post.publish_at " +
+			"FROM post WHERE post.uid = ? AND NOT " + postPublished + " ORDER BY post.timestamp DESC, post.postid DESC"
+	)
+
+	var posts []Post
+
+	// Make the query
+	rows, err := db.c.Query(postsQuery, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var post Post
+		var publishAt sql.NullString
+		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption, &post.Status, &publishAt)
+		if err != nil {
+			return nil, err
+		}
+		post.PublishAt = publishAt.String
+
+		// Get comments
+		post.Comments, err = db.GetPostComments(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get media
+		post.Media, err = db.GetPostMedia(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get reactions
+		post.Reactions, err = db.GetReactions(post.Postid, 0)
+		if err != nil {
+			return posts, err
+		}
+
+		// Add post to the list
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// GetExpiredStories allows to get all the stories that are expired at now, so that they can be removed.
+func (db *appdbimpl) GetExpiredStories(now time.Time) ([]Story, error) {
+	rows, err := db.c.Query("SELECT storyid, uid, format, width, height, timestamp, expiration FROM story WHERE expiration <= ?",
+		databaseTime(now))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var stories []Story
+	for rows.Next() {
+		var story Story
+		err = rows.Scan(&story.Storyid, &story.Uid, &story.Format, &story.Width, &story.Height, &story.Datetime, &story.Expiration)
+		if err != nil {
+			return stories, err
+		}
+		stories = append(stories, story)
+	}
+
+	if rows.Err() != nil {
+		return stories, rows.Err()
+	}
+
+	return stories, nil
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetExplorePosts allows to get the popular posts uploaded in the last `hours` hours by users that uid doesn't follow.
+// Posts are ranked by their engagement velocity: reactions (likes included) and comments (weighted double) divided by the square of the
+// post age, so that recent posts with a lot of interactions come first.
+// Posts of users that have banned uid, or that uid has banned, posts of deactivated users and posts hidden by the
+// moderation are excluded.
+// offset and limit select the page of posts to return.
+func (db *appdbimpl) GetExplorePosts(uid uint64, hours uint64, offset uint64, limit uint64) ([]Post, error) {
+	const (
+		postsQuery = "WITH candidate AS (SELECT post.postid, post.uid, post.timestamp, post.caption, " +
+			"(SELECT COUNT(*) FROM reaction WHERE reaction.postid = post.postid AND reaction.commentid = 0) AS likes, " +
+			"(SELECT COUNT(*) FROM comment WHERE comment.postid = post.postid AND " + commentVisible + ") AS comments, " +
+			"(julianday('now', '+1 hours') - julianday(post.timestamp)) * 24 AS age " +
+			"FROM post WHERE post.timestamp >= datetime('now', '+1 hours', ?) " +
+			"AND post.uid != ? AND " + postVisible + " " +
+			"AND post.uid NOT IN (SELECT follow.fuid FROM follow WHERE follow.uid = ?) " +
+			"AND post.uid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+			"AND post.uid NOT IN (SELECT ban.buid FROM ban WHERE ban.uid = ?) " +
+			"AND post.uid NOT IN (" + inactiveUsers + ")) " +
+			"SELECT postid, uid, timestamp, caption FROM candidate " +
+			"ORDER BY (likes + 2.0 * comments) / ((age + 2) * (age + 2)) DESC, timestamp DESC, postid DESC " +
+			"LIMIT ? OFFSET ?"
+	)
+
+	var posts []Post
+
+	// Make the query
+	rows, err := db.c.Query(postsQuery, fmt.Sprintf("-%d hours", hours), uid, uid, uid, uid, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var post Post
+		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption)
+		if err != nil {
+			return nil, err
+		}
+
+		// Post correctly readed, get number of likes
+		likes, err := db.GetPostLikes(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+		post.Likes = uint64(len(likes))
+
+		// Get comments
+		post.Comments, err = db.GetPostComments(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get media
+		post.Media, err = db.GetPostMedia(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get reactions
+		post.Reactions, err = db.GetReactions(post.Postid, 0)
+		if err != nil {
+			return posts, err
+		}
+
+		// Add post to the list
+		posts = append(posts, post)
+	}
+
+	if rows.Err() != nil {
+		return posts, rows.Err()
+	}
+
+	return posts, err
+}
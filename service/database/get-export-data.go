@@ -0,0 +1,112 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetExportData allows to get all the personal data of a user to export: his profile, all his posts (hidden and
+// unpublished ones included, with their media and status), his comments and reactions on any post, the users that he
+// follows, his followers and the users that he has banned. Comments and reactions have no mentions and counters.
+// Request will fail if uid doesn't exist
+func (db *appdbimpl) GetExportData(uid uint64) (ExportData, error) {
+	const (
+		postsQuery = "SELECT postid, uid, timestamp, caption, status, publish_at FROM post WHERE uid = ? " +
+			"ORDER BY timestamp, postid"
+		commentsQuery  = "SELECT commentid, message, timestamp, postid, uid FROM comment WHERE uid = ? ORDER BY commentid"
+		reactionsQuery = "SELECT postid, commentid, type, timestamp FROM reaction WHERE uid = ? ORDER BY postid, commentid"
+		followingQuery = "SELECT user.uid, user.username FROM follow JOIN user ON user.uid = follow.fuid " +
+			"WHERE follow.uid = ? ORDER BY user.uid"
+		followersQuery = "SELECT user.uid, user.username FROM follow JOIN user ON user.uid = follow.uid " +
+			"WHERE follow.fuid = ? ORDER BY user.uid"
+		bannedQuery = "SELECT user.uid, user.username FROM ban JOIN user ON user.uid = ban.buid " +
+			"WHERE ban.uid = ? ORDER BY user.uid"
+	)
+
+	var data ExportData
+	var err error
+	data.Profile, err = db.GetProfileInfo(uid)
+	if err != nil {
+		return data, err
+	}
+
+	err = db.queryRows(postsQuery, uid, func(rows *sql.Rows) error {
+		var post Post
+		var publishAt sql.NullString
+		err := rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption, &post.Status, &publishAt)
+		post.PublishAt = publishAt.String
+		data.Posts = append(data.Posts, post)
+		return err
+	})
+	if err != nil {
+		return data, err
+	}
+
+	for i := range data.Posts {
+		data.Posts[i].Media, err = db.GetPostMedia(data.Posts[i].Postid)
+		if err != nil {
+			return data, err
+		}
+	}
+
+	err = db.queryRows(commentsQuery, uid, func(rows *sql.Rows) error {
+		var comment Comment
+		err := rows.Scan(&comment.Commentid, &comment.Message, &comment.Datetime, &comment.Postid, &comment.Userid)
+		data.Comments = append(data.Comments, comment)
+		return err
+	})
+	if err != nil {
+		return data, err
+	}
+
+	err = db.queryRows(reactionsQuery, uid, func(rows *sql.Rows) error {
+		var reaction UserReaction
+		// Reactions converted from the old likes have no timestamp
+		var datetime sql.NullString
+		err := rows.Scan(&reaction.Postid, &reaction.Commentid, &reaction.Type, &datetime)
+		reaction.Datetime = datetime.String
+		data.Reactions = append(data.Reactions, reaction)
+		return err
+	})
+	if err != nil {
+		return data, err
+	}
+
+	users := map[string]*[]User{
+		followingQuery: &data.Following,
+		followersQuery: &data.Followers,
+		bannedQuery:    &data.Banned,
+	}
+	for query, list := range users {
+		list := list
+		err = db.queryRows(query, uid, func(rows *sql.Rows) error {
+			var user User
+			err := rows.Scan(&user.Userid, &user.Username)
+			*list = append(*list, user)
+			return err
+		})
+		if err != nil {
+			return data, err
+		}
+	}
+
+	return data, nil
+}
+
+// queryRows executes a query with a single argument and calls scan for each row returned.
+func (db *appdbimpl) queryRows(query string, arg interface{}, scan func(rows *sql.Rows) error) error {
+	rows, err := db.c.Query(query, arg)
+	if err != nil {
+		return err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	// exportsQuery selects the exports; expiration is NULL until the archive is built
+	exportsQuery = "SELECT exportid, uid, status, token, timestamp, expiration FROM export "
+)
+
+// GetLastExport allows to get the last export requested by a user.
+// Request will fail if the user has never requested an export.
+func (db *appdbimpl) GetLastExport(uid uint64) (Export, error) {
+	return scanExport(db.c.QueryRow(exportsQuery+"WHERE uid = ? ORDER BY exportid DESC LIMIT 1", uid))
+}
+
+// GetExportByToken allows to get the ready export whose download link has the secret token, if it's not expired at
+// now.
+// Request will fail if there is no such export.
+func (db *appdbimpl) GetExportByToken(token string, now time.Time) (Export, error) {
+	return scanExport(db.c.QueryRow(exportsQuery+"WHERE token = ? AND token != '' AND status = ? AND expiration > ?",
+		token, ExportReady, databaseTime(now)))
+}
+
+// GetPendingExports allows to get the exports whose archive has to be built, the oldest first.
+func (db *appdbimpl) GetPendingExports() ([]Export, error) {
+	return db.getExports(exportsQuery+"WHERE status = ? ORDER BY exportid", ExportPending)
+}
+
+// GetExpiredExports allows to get the exports expired at now, so that they can be removed.
+func (db *appdbimpl) GetExpiredExports(now time.Time) ([]Export, error) {
+	return db.getExports(exportsQuery+"WHERE expiration IS NOT NULL AND expiration <= ?", databaseTime(now))
+}
+
+// getExports returns the exports selected by query, that must select the columns of exportsQuery.
+func (db *appdbimpl) getExports(query string, args ...interface{}) ([]Export, error) {
+	rows, err := db.c.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var exports []Export
+	for rows.Next() {
+		export, err := scanExport(rows)
+		if err != nil {
+			return exports, err
+		}
+		exports = append(exports, export)
+	}
+
+	if rows.Err() != nil {
+		return exports, rows.Err()
+	}
+
+	return exports, nil
+}
+
+// scanExport reads an export from a row with the columns of exportsQuery.
+func scanExport(row interface{ Scan(...interface{}) error }) (Export, error) {
+	var export Export
+	var expiration sql.NullString
+	err := row.Scan(&export.Exportid, &export.Uid, &export.Status, &export.Token, &export.Datetime, &expiration)
+	export.Expiration = expiration.String
+	return export, err
+}
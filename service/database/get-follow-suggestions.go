@@ -0,0 +1,52 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetFollowSuggestions allows to get the users followed by the users that uid follows (friends of friends), ranked by
+// the number of mutual connections.
+// Users already followed by uid, users that have banned uid, users banned by uid and deactivated (or deleted) users are
+// excluded. Mutual connections that have banned uid are not counted, so their follow list is not disclosed.
+// limit is the maximum number of suggestions to return.
+func (db *appdbimpl) GetFollowSuggestions(uid uint64, limit uint64) ([]Suggestion, error) {
+	const (
+		suggestionsQuery = "SELECT candidate.fuid, user.username, COUNT(*) AS mutuals, MIN(mutual.username) " +
+			"FROM follow AS followed " +
+			"JOIN follow AS candidate ON candidate.uid = followed.fuid " +
+			"JOIN user ON user.uid = candidate.fuid " +
+			"JOIN user AS mutual ON mutual.uid = followed.fuid " +
+			"WHERE followed.uid = ? AND candidate.fuid != ? " +
+			"AND candidate.fuid NOT IN (SELECT follow.fuid FROM follow WHERE follow.uid = ?) " +
+			"AND candidate.fuid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+			"AND candidate.fuid NOT IN (SELECT ban.buid FROM ban WHERE ban.uid = ?) " +
+			"AND followed.fuid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+			"AND user.status NOT IN (" + inactiveStatuses + ") AND mutual.status NOT IN (" + inactiveStatuses + ") " +
+			"GROUP BY candidate.fuid, user.username " +
+			"ORDER BY mutuals DESC, user.username LIMIT ?"
+	)
+
+	rows, err := db.c.Query(suggestionsQuery, uid, uid, uid, uid, uid, uid, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var suggestions []Suggestion
+	for rows.Next() {
+		var suggestion Suggestion
+		err = rows.Scan(&suggestion.User.Userid, &suggestion.User.Username, &suggestion.Mutuals, &suggestion.Via)
+		if err != nil {
+			return suggestions, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if rows.Err() != nil {
+		return suggestions, rows.Err()
+	}
+
+	return suggestions, nil
+}
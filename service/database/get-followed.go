@@ -5,10 +5,11 @@ import (
 )
 
 // GetFollowed allows to get a []uint64 followed ids for a specified user.
+// Deactivated followed users are excluded.
 // Request will fail if uid doesn't exist
 func (db *appdbimpl) GetFollowed(uid uint64) ([]uint64, error) {
 	const (
-		getFollowed = "SELECT follow.fuid from follow WHERE follow.uid = ?"
+		getFollowed = "SELECT follow.fuid from follow WHERE follow.uid = ? AND follow.fuid NOT IN (" + inactiveUsers + ")"
 	)
 
 	var fuids []uint64
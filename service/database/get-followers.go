@@ -5,10 +5,11 @@ import (
 )
 
 // GetFollowers allows to get a []uint64 followers ids for a specified user.
+// Deactivated followers are excluded.
 // Request will fail if uid doesn't exist
 func (db *appdbimpl) GetFollowers(uid uint64) ([]uint64, error) {
 	const (
-		getFollowers = "SELECT follow.uid from follow WHERE follow.fuid = ?"
+		getFollowers = "SELECT follow.uid from follow WHERE follow.fuid = ? AND follow.uid NOT IN (" + inactiveUsers + ")"
 	)
 
 	var fuids []uint64
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetLikedComments allows to get the ids of the comments under a post that uid has liked.
+func (db *appdbimpl) GetLikedComments(postid uint64, userid uint64) ([]uint64, error) {
+	rows, err := db.c.Query("SELECT commentid FROM comment_like WHERE postid = ? AND uid = ?", postid, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var comments []uint64
+	for rows.Next() {
+		var commentid uint64
+		err = rows.Scan(&commentid)
+		if err != nil {
+			return comments, err
+		}
+		comments = append(comments, commentid)
+	}
+
+	if rows.Err() != nil {
+		return comments, rows.Err()
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,9 @@
+package database
+
+// GetMediaPostid allows to get the id of the post that a media belongs to.
+// Request will fail if the media doesn't exist.
+func (db *appdbimpl) GetMediaPostid(mediaid uint64) (uint64, error) {
+	var postid uint64
+	err := db.c.QueryRow("SELECT postid FROM post_media WHERE mediaid = ?", mediaid).Scan(&postid)
+	return postid, err
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetMediaWithoutInfo allows to get the media whose format and size are not known yet (the ones of the posts created
+// before carousel support).
+func (db *appdbimpl) GetMediaWithoutInfo() ([]Media, error) {
+	const (
+		mediaQuery = "SELECT mediaid, postid, format, width, height FROM post_media WHERE format = '' ORDER BY mediaid"
+	)
+
+	rows, err := db.c.Query(mediaQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var media []Media
+	for rows.Next() {
+		var item Media
+		err = rows.Scan(&item.Mediaid, &item.Postid, &item.Format, &item.Width, &item.Height)
+		if err != nil {
+			return media, err
+		}
+		media = append(media, item)
+	}
+
+	if rows.Err() != nil {
+		return media, rows.Err()
+	}
+
+	return media, nil
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetModerationActions allows to get the moderation audit trail in reverse chronological order.
+// offset and limit select the page of actions to return.
+func (db *appdbimpl) GetModerationActions(offset uint64, limit uint64) ([]ModerationAction, error) {
+	const (
+		actionsQuery = "SELECT actionid, actor, action, uid, reportid, postid, commentid, detail, timestamp " +
+			"FROM moderation_action ORDER BY actionid DESC LIMIT ? OFFSET ?"
+	)
+
+	rows, err := db.c.Query(actionsQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var actions []ModerationAction
+	for rows.Next() {
+		var action ModerationAction
+		err = rows.Scan(&action.Actionid, &action.Actor, &action.Action, &action.Uid, &action.Reportid, &action.Postid,
+			&action.Commentid, &action.Detail, &action.Datetime)
+		if err != nil {
+			return actions, err
+		}
+		actions = append(actions, action)
+	}
+
+	if rows.Err() != nil {
+		return actions, rows.Err()
+	}
+
+	return actions, nil
+}
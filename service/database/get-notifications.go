@@ -0,0 +1,39 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetNotifications allows to get the notifications of a user in reverse chronological order.
+// offset and limit select the page of notifications to return.
+func (db *appdbimpl) GetNotifications(uid uint64, offset uint64, limit uint64) ([]Notification, error) {
+	const (
+		notificationsQuery = "SELECT notificationid, uid, type, actor, postid, commentid, timestamp FROM notification " +
+			"WHERE uid = ? ORDER BY timestamp DESC, notificationid DESC LIMIT ? OFFSET ?"
+	)
+
+	rows, err := db.c.Query(notificationsQuery, uid, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var notifications []Notification
+	for rows.Next() {
+		var notification Notification
+		err = rows.Scan(&notification.Notificationid, &notification.Uid, &notification.Type, &notification.Actor,
+			&notification.Postid, &notification.Commentid, &notification.Datetime)
+		if err != nil {
+			return notifications, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if rows.Err() != nil {
+		return notifications, rows.Err()
+	}
+
+	return notifications, nil
+}
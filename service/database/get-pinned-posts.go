@@ -0,0 +1,68 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetPinnedPosts allows to get the posts pinned by a user to the top of his profile, the last pinned first.
+// Posts hidden by the moderation are excluded.
+func (db *appdbimpl) GetPinnedPosts(uid uint64) ([]Post, error) {
+	const (
+		postsQuery = "SELECT post.postid, post.uid, post.timestamp, post.caption FROM post WHERE post.uid = ? AND " + postVisible +
+			" AND post.pinned IS NOT NULL ORDER BY post.pinned DESC"
+	)
+
+	var posts []Post
+
+	// Make the query
+	rows, err := db.c.Query(postsQuery, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var post Post
+		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption)
+		if err != nil {
+			return nil, err
+		}
+
+		// Post correctly readed, get number of likes
+		likes, err := db.GetPostLikes(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+		post.Likes = uint64(len(likes))
+
+		// Get comments
+		post.Comments, err = db.GetPostComments(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get media
+		post.Media, err = db.GetPostMedia(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get reactions
+		post.Reactions, err = db.GetReactions(post.Postid, 0)
+		if err != nil {
+			return posts, err
+		}
+
+		// Add post to the list
+		posts = append(posts, post)
+	}
+
+	if rows.Err() != nil {
+		return posts, rows.Err()
+	}
+
+	return posts, err
+
+}
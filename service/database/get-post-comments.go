@@ -5,11 +5,14 @@ import (
 	"errors"
 )
 
-// GetPostComments allows to get all the comments under a post.
+// GetPostComments allows to get all the comments under a post, with their number of likes. Comments hidden by the
+// moderation are excluded.
 // Request will fail if postid doesn't exist
 func (db *appdbimpl) GetPostComments(postid uint64) ([]Comment, error) {
 	const (
-		commentQuery = "SELECT * FROM comment WHERE postid = ?"
+		commentQuery = "SELECT commentid, message, timestamp, postid, uid, " +
+			"(SELECT COUNT(*) FROM comment_like WHERE comment_like.commentid = comment.commentid) " +
+			"FROM comment WHERE postid = ? AND " + commentVisible
 	)
 
 	// First check if post exist
@@ -32,7 +35,19 @@ func (db *appdbimpl) GetPostComments(postid uint64) ([]Comment, error) {
 
 	for rows.Next() {
 		var comment Comment
-		err = rows.Scan(&comment.Commentid, &comment.Message, &comment.Datetime, &comment.Postid, &comment.Userid)
+		err = rows.Scan(&comment.Commentid, &comment.Message, &comment.Datetime, &comment.Postid, &comment.Userid, &comment.Likes)
+		if err != nil {
+			return comments, err
+		}
+
+		// Get mentions
+		comment.Mentions, err = db.GetCommentMentions(comment.Commentid)
+		if err != nil {
+			return comments, err
+		}
+
+		// Get reactions
+		comment.Reactions, err = db.GetReactions(postid, comment.Commentid)
 		if err != nil {
 			return comments, err
 		}
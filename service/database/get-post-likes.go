@@ -4,16 +4,16 @@ import (
 	"database/sql"
 )
 
-// GetPostLikes allows to get a []uint64 follower ids that put like to a specified post.
+// GetPostLikes allows to get a []uint64 follower ids that put like (the ReactionHeart reaction) to a specified post.
 // Request will fail if postid doesn't exist
 func (db *appdbimpl) GetPostLikes(postid uint64) ([]uint64, error) {
 	const (
-		likesQuery = "SELECT like.uid FROM like WHERE like.postid = ?"
+		likesQuery = "SELECT reaction.uid FROM reaction WHERE reaction.postid = ? AND reaction.commentid = 0 AND reaction.type = ?"
 	)
 
 	var uidlikes []uint64
 
-	rows, err := db.c.Query(likesQuery, postid)
+	rows, err := db.c.Query(likesQuery, postid, ReactionHeart)
 	if err != nil {
 		return nil, err
 	}
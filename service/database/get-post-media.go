@@ -0,0 +1,36 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetPostMedia allows to get the media of a post, in the order of the carousel.
+func (db *appdbimpl) GetPostMedia(postid uint64) ([]Media, error) {
+	const (
+		mediaQuery = "SELECT mediaid, postid, format, width, height FROM post_media WHERE postid = ? ORDER BY position"
+	)
+
+	rows, err := db.c.Query(mediaQuery, postid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var media []Media
+	for rows.Next() {
+		var item Media
+		err = rows.Scan(&item.Mediaid, &item.Postid, &item.Format, &item.Width, &item.Height)
+		if err != nil {
+			return media, err
+		}
+		media = append(media, item)
+	}
+
+	if rows.Err() != nil {
+		return media, rows.Err()
+	}
+
+	return media, nil
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"errors"
 )
 
@@ -8,7 +9,7 @@ import (
 // Request will fail if postid doesn't exist
 func (db *appdbimpl) GetPost(postid uint64) (Post, error) {
 	const (
-		postQuery = "SELECT * FROM post WHERE postid = ?"
+		postQuery = "SELECT postid, uid, timestamp, caption, status, publish_at, archived FROM post WHERE postid = ?"
 	)
 
 	// First check if post exist
@@ -21,10 +22,13 @@ func (db *appdbimpl) GetPost(postid uint64) (Post, error) {
 	}
 
 	var postDB Post
-	err = db.c.QueryRow(postQuery, postid).Scan(&postDB.Postid, &postDB.Uid, &postDB.Datetime)
+	var publishAt sql.NullString
+	err = db.c.QueryRow(postQuery, postid).Scan(&postDB.Postid, &postDB.Uid, &postDB.Datetime, &postDB.Caption,
+		&postDB.Status, &publishAt, &postDB.Archived)
 	if err != nil {
 		return Post{}, err
 	}
+	postDB.PublishAt = publishAt.String
 
 	likes, err := db.GetPostLikes(postid)
 	if err != nil {
@@ -38,5 +42,15 @@ func (db *appdbimpl) GetPost(postid uint64) (Post, error) {
 	}
 	postDB.Comments = comments
 
+	postDB.Media, err = db.GetPostMedia(postid)
+	if err != nil {
+		return Post{}, err
+	}
+
+	postDB.Reactions, err = db.GetReactions(postid, 0)
+	if err != nil {
+		return Post{}, err
+	}
+
 	return postDB, nil
 }
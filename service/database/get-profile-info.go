@@ -4,7 +4,7 @@ package database
 // Request will fail if uid doesn't exist
 func (db *appdbimpl) GetProfileInfo(uid uint64) (Profile, error) {
 	const (
-		getNumberPostQuery = "SELECT COUNT(*) FROM post WHERE post.uid = ?"
+		getNumberPostQuery = "SELECT COUNT(*) FROM post WHERE post.uid = ? AND " + postVisible
 	)
 
 	var profile Profile
@@ -4,11 +4,13 @@ import (
 	"database/sql"
 )
 
-// GetProfilePosts allows to get profile Posts stream passing his uid.
+// GetProfilePosts allows to get profile Posts stream passing his uid. Posts hidden by the moderation and the posts
+// pinned by the user (see GetPinnedPosts) are excluded.
 // Request will fail if uid doesn't exist
 func (db *appdbimpl) GetProfilePosts(uid uint64) ([]Post, error) {
 	const (
-		postsQuery = "SELECT post.postid, post.uid, post.timestamp FROM post WHERE post.uid = ? ORDER BY post.timestamp DESC"
+		postsQuery = "SELECT post.postid, post.uid, post.timestamp, post.caption FROM post WHERE post.uid = ? AND " + postVisible +
+			" AND post.pinned IS NULL ORDER BY post.timestamp DESC"
 	)
 
 	var posts []Post
@@ -24,7 +26,7 @@ func (db *appdbimpl) GetProfilePosts(uid uint64) ([]Post, error) {
 
 	for rows.Next() {
 		var post Post
-		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime)
+		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption)
 		if err != nil {
 			return nil, err
 		}
@@ -42,6 +44,18 @@ func (db *appdbimpl) GetProfilePosts(uid uint64) ([]Post, error) {
 			return posts, err
 		}
 
+		// Get media
+		post.Media, err = db.GetPostMedia(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get reactions
+		post.Reactions, err = db.GetReactions(post.Postid, 0)
+		if err != nil {
+			return posts, err
+		}
+
 		// Add post to the list
 		posts = append(posts, post)
 	}
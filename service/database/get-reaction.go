@@ -0,0 +1,17 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// GetReaction allows to get the reaction of uid to a post (commentid = 0) or to one of its comments.
+// Function will return "" if uid has not reacted.
+func (db *appdbimpl) GetReaction(postid uint64, commentid uint64, userid uint64) (string, error) {
+	var reaction string
+	err := db.c.QueryRow("SELECT type FROM reaction WHERE postid = ? AND commentid = ? AND uid = ?", postid, commentid, userid).Scan(&reaction)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return reaction, err
+}
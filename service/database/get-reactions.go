@@ -0,0 +1,34 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetReactions allows to get the number of reactions of each type to a post (commentid = 0) or to one of its
+// comments. Types without reactions are not present.
+func (db *appdbimpl) GetReactions(postid uint64, commentid uint64) (map[string]uint64, error) {
+	rows, err := db.c.Query("SELECT type, COUNT(*) FROM reaction WHERE postid = ? AND commentid = ? GROUP BY type", postid, commentid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	reactions := map[string]uint64{}
+	for rows.Next() {
+		var reaction string
+		var count uint64
+		err = rows.Scan(&reaction, &count)
+		if err != nil {
+			return reactions, err
+		}
+		reactions[reaction] = count
+	}
+
+	if rows.Err() != nil {
+		return reactions, rows.Err()
+	}
+
+	return reactions, nil
+}
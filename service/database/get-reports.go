@@ -0,0 +1,54 @@
+package database
+
+import (
+	"database/sql"
+)
+
+const (
+	// reportsQuery selects the reports with their reporter and the number of open reports of the same content
+	reportsQuery = "SELECT report.reportid, report.uid, user.username, report.postid, report.commentid, report.reason, " +
+		"report.status, report.timestamp, " +
+		"(SELECT COUNT(*) FROM report AS other WHERE other.postid = report.postid " +
+		"AND other.commentid = report.commentid AND other.status = '" + ReportOpen + "') " +
+		"FROM report JOIN user ON user.uid = report.uid "
+)
+
+// GetReport allows to get a report.
+// Request will fail if reportid doesn't exist.
+func (db *appdbimpl) GetReport(reportid uint64) (Report, error) {
+	var report Report
+	err := db.c.QueryRow(reportsQuery+"WHERE report.reportid = ?", reportid).Scan(&report.Reportid,
+		&report.Reporter.Userid, &report.Reporter.Username, &report.Postid, &report.Commentid, &report.Reason,
+		&report.Status, &report.Datetime, &report.Reports)
+	return report, err
+}
+
+// GetReports allows to get the reports with a status (all the reports if status is empty), the oldest first.
+// offset and limit select the page of reports to return.
+func (db *appdbimpl) GetReports(status string, offset uint64, limit uint64) ([]Report, error) {
+	rows, err := db.c.Query(reportsQuery+"WHERE ? = '' OR report.status = ? ORDER BY report.reportid LIMIT ? OFFSET ?",
+		status, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var reports []Report
+	for rows.Next() {
+		var report Report
+		err = rows.Scan(&report.Reportid, &report.Reporter.Userid, &report.Reporter.Username, &report.Postid,
+			&report.Commentid, &report.Reason, &report.Status, &report.Datetime, &report.Reports)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+
+	if rows.Err() != nil {
+		return reports, rows.Err()
+	}
+
+	return reports, nil
+}
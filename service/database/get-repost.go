@@ -0,0 +1,19 @@
+package database
+
+// GetRepost allows to get a repost, with the reposting user and the username of the original post owner.
+// Request will fail if repostid doesn't exist
+func (db *appdbimpl) GetRepost(repostid uint64) (Repost, error) {
+	const (
+		repostQuery = "SELECT repost.repostid, repost.uid, reposter.username, repost.postid, owner.username, " +
+			"repost.quote, repost.timestamp FROM repost " +
+			"JOIN user AS reposter ON reposter.uid = repost.uid " +
+			"JOIN post ON post.postid = repost.postid " +
+			"JOIN user AS owner ON owner.uid = post.uid " +
+			"WHERE repost.repostid = ?"
+	)
+
+	var repost Repost
+	err := db.c.QueryRow(repostQuery, repostid).Scan(&repost.Repostid, &repost.User.Userid, &repost.User.Username,
+		&repost.Postid, &repost.Owner, &repost.Quote, &repost.Datetime)
+	return repost, err
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetSavedPosts allows to get the posts saved by a user in a collection (collectionid = 0 for all the saved posts),
+// in reverse chronological order of saving.
+// Posts that have been deleted or hidden by the moderation, or whose owner has banned uid (or banned by him), are not
+// returned.
+// offset and limit select the page of posts to return.
+func (db *appdbimpl) GetSavedPosts(uid uint64, collectionid uint64, offset uint64, limit uint64) ([]Post, error) {
+	const (
+		postsQuery = "SELECT post.postid, post.uid, post.timestamp, post.caption FROM saved_post " +
+			"JOIN post ON post.postid = saved_post.postid " +
+			"WHERE saved_post.uid = ? AND (? = 0 OR saved_post.collectionid = ?) AND " + postVisible + " " +
+			"AND post.uid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+			"AND post.uid NOT IN (SELECT ban.buid FROM ban WHERE ban.uid = ?) " +
+			"ORDER BY saved_post.timestamp DESC, saved_post.rowid DESC LIMIT ? OFFSET ?"
+	)
+
+	var posts []Post
+
+	// Make the query
+	rows, err := db.c.Query(postsQuery, uid, collectionid, collectionid, uid, uid, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var post Post
+		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption)
+		if err != nil {
+			return nil, err
+		}
+
+		// Post correctly readed, get number of likes
+		likes, err := db.GetPostLikes(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+		post.Likes = uint64(len(likes))
+
+		// Get comments
+		post.Comments, err = db.GetPostComments(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get media
+		post.Media, err = db.GetPostMedia(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get reactions
+		post.Reactions, err = db.GetReactions(post.Postid, 0)
+		if err != nil {
+			return posts, err
+		}
+
+		// Add post to the list
+		posts = append(posts, post)
+	}
+
+	if rows.Err() != nil {
+		return posts, rows.Err()
+	}
+
+	return posts, err
+}
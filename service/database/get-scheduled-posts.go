@@ -0,0 +1,30 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// GetScheduledPosts allows to get the scheduled posts whose publish_at time has passed at the time `now`, so that they
+// can be published. Only the postid and the uid of the posts are returned.
+func (db *appdbimpl) GetScheduledPosts(now time.Time) ([]Post, error) {
+	rows, err := db.c.Query("SELECT postid, uid FROM post WHERE status = ? AND publish_at <= ? ORDER BY publish_at",
+		PostScheduled, databaseTime(now))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var posts []Post
+	for rows.Next() {
+		var post Post
+		if err = rows.Scan(&post.Postid, &post.Uid); err != nil {
+			return posts, err
+		}
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
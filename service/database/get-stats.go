@@ -0,0 +1,28 @@
+package database
+
+import (
+	"time"
+)
+
+// GetStats allows to get the system statistics at the time `now`. Deleted users are not counted.
+func (db *appdbimpl) GetStats(now time.Time) (Stats, error) {
+	const (
+		statsQuery = "SELECT " +
+			"(SELECT COUNT(*) FROM user WHERE status NOT IN ('" + StatusDeleting + "', '" + StatusDeleted + "')), " +
+			"(SELECT COUNT(*) FROM user WHERE status = '" + StatusSuspended + "' " +
+			"AND (status_until IS NULL OR status_until > ?1)), " +
+			"(SELECT COUNT(*) FROM post), " +
+			"(SELECT COUNT(*) FROM post WHERE post.hidden = 1 AND " + postPublished + "), " +
+			"(SELECT COUNT(*) FROM comment), " +
+			"(SELECT COUNT(*) FROM comment WHERE NOT " + commentVisible + "), " +
+			"(SELECT COUNT(*) FROM reaction), " +
+			"(SELECT COUNT(*) FROM story WHERE expiration > ?1), " +
+			"(SELECT COUNT(*) FROM report WHERE status = '" + ReportOpen + "')"
+	)
+
+	var stats Stats
+	err := db.c.QueryRow(statsQuery, databaseTime(now)).Scan(&stats.Users, &stats.SuspendedUsers, &stats.Posts,
+		&stats.HiddenPosts, &stats.Comments, &stats.HiddenComments, &stats.Reactions, &stats.ActiveStories,
+		&stats.OpenReports)
+	return stats, err
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetStoryViewers allows to get the users that have seen a story, from the most recent view.
+func (db *appdbimpl) GetStoryViewers(storyid uint64) ([]StoryView, error) {
+	const (
+		viewersQuery = "SELECT user.uid, user.username, story_view.timestamp FROM story_view " +
+			"JOIN user ON user.uid = story_view.uid " +
+			"WHERE story_view.storyid = ? ORDER BY story_view.timestamp DESC, user.username"
+	)
+
+	rows, err := db.c.Query(viewersQuery, storyid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var viewers []StoryView
+	for rows.Next() {
+		var viewer StoryView
+		err = rows.Scan(&viewer.User.Userid, &viewer.User.Username, &viewer.Datetime)
+		if err != nil {
+			return viewers, err
+		}
+		viewers = append(viewers, viewer)
+	}
+
+	if rows.Err() != nil {
+		return viewers, rows.Err()
+	}
+
+	return viewers, nil
+}
@@ -0,0 +1,12 @@
+package database
+
+// GetStory allows to get the information related to a story, even if it's expired.
+// Request will fail if storyid doesn't exist
+func (db *appdbimpl) GetStory(storyid uint64) (Story, error) {
+	var story Story
+	err := db.c.QueryRow("SELECT storyid, uid, format, width, height, timestamp, expiration, "+
+		"(SELECT COUNT(*) FROM story_view WHERE story_view.storyid = story.storyid) FROM story WHERE storyid = ?",
+		storyid).Scan(&story.Storyid, &story.Uid, &story.Format, &story.Width, &story.Height, &story.Datetime,
+		&story.Expiration, &story.Views)
+	return story, err
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// GetStreamStories allows to get the stories, not expired at now, of the users followed by uid. Stories are ordered by
+// owner and then in upload order, and Seen reports if uid has already seen them.
+// Stories of users that have banned uid, or that uid has banned, and of deactivated users are excluded.
+func (db *appdbimpl) GetStreamStories(uid uint64, now time.Time) ([]Story, error) {
+	const (
+		storiesQuery = "SELECT story.storyid, story.uid, story.format, story.width, story.height, story.timestamp, " +
+			"story.expiration, EXISTS (SELECT 1 FROM story_view WHERE story_view.storyid = story.storyid AND story_view.uid = ?) " +
+			"FROM story JOIN follow ON follow.fuid = story.uid " +
+			"WHERE follow.uid = ? AND story.expiration > ? " +
+			"AND story.uid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+			"AND story.uid NOT IN (SELECT ban.buid FROM ban WHERE ban.uid = ?) " +
+			"AND story.uid NOT IN (" + inactiveUsers + ") " +
+			"ORDER BY story.uid, story.timestamp, story.storyid"
+	)
+
+	rows, err := db.c.Query(storiesQuery, uid, uid, databaseTime(now), uid, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var stories []Story
+	for rows.Next() {
+		var story Story
+		err = rows.Scan(&story.Storyid, &story.Uid, &story.Format, &story.Width, &story.Height, &story.Datetime,
+			&story.Expiration, &story.Seen)
+		if err != nil {
+			return stories, err
+		}
+		stories = append(stories, story)
+	}
+
+	if rows.Err() != nil {
+		return stories, rows.Err()
+	}
+
+	return stories, nil
+}
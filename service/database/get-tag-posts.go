@@ -0,0 +1,71 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetTagPosts allows to get the Posts that use a hashtag (in the caption or in a comment) in reverse chronological
+// order. Posts of users that have banned uid, or that uid has banned, and posts hidden by the moderation are excluded.
+// offset and limit select the page of posts to return.
+func (db *appdbimpl) GetTagPosts(tag string, uid uint64, offset uint64, limit uint64) ([]Post, error) {
+	const (
+		postsQuery = "SELECT post.postid, post.uid, post.timestamp, post.caption FROM post " +
+			"WHERE post.postid IN (SELECT post_tag.postid FROM post_tag WHERE post_tag.tag = ?) AND " + postVisible + " " +
+			"AND post.uid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+			"AND post.uid NOT IN (SELECT ban.buid FROM ban WHERE ban.uid = ?) " +
+			"ORDER BY post.timestamp DESC, post.postid DESC LIMIT ? OFFSET ?"
+	)
+
+	var posts []Post
+
+	// Make the query
+	rows, err := db.c.Query(postsQuery, tag, uid, uid, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var post Post
+		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption)
+		if err != nil {
+			return nil, err
+		}
+
+		// Post correctly readed, get number of likes
+		likes, err := db.GetPostLikes(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+		post.Likes = uint64(len(likes))
+
+		// Get comments
+		post.Comments, err = db.GetPostComments(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get media
+		post.Media, err = db.GetPostMedia(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get reactions
+		post.Reactions, err = db.GetReactions(post.Postid, 0)
+		if err != nil {
+			return posts, err
+		}
+
+		// Add post to the list
+		posts = append(posts, post)
+	}
+
+	if rows.Err() != nil {
+		return posts, rows.Err()
+	}
+
+	return posts, err
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetTrendingTags allows to get the most used hashtags in the last `hours` hours.
+// Tags are ordered by the number of distinct posts that used them in the time window. Posts that are unpublished,
+// archived or hidden by the moderation, posts of deactivated users and comments hidden by the moderation are ignored.
+func (db *appdbimpl) GetTrendingTags(hours uint64, limit uint64) ([]Tag, error) {
+	const (
+		trendingQuery = "SELECT post_tag.tag, COUNT(DISTINCT post_tag.postid) AS uses FROM post_tag " +
+			"WHERE post_tag.timestamp >= datetime('now', '+1 hours', ?) " +
+			"AND post_tag.postid IN (SELECT post.postid FROM post WHERE " + postVisible + " " +
+			"AND post.uid NOT IN (" + inactiveUsers + ")) " +
+			"AND (post_tag.commentid = 0 OR post_tag.commentid IN (SELECT comment.commentid FROM comment WHERE " + commentVisible + ")) " +
+			"GROUP BY post_tag.tag ORDER BY uses DESC, post_tag.tag ASC LIMIT ?"
+	)
+
+	rows, err := db.c.Query(trendingQuery, fmt.Sprintf("-%d hours", hours), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var tags []Tag
+	for rows.Next() {
+		var tag Tag
+		err = rows.Scan(&tag.Name, &tag.Posts)
+		if err != nil {
+			return tags, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if rows.Err() != nil {
+		return tags, rows.Err()
+	}
+
+	return tags, nil
+}
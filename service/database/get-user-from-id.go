@@ -4,6 +4,6 @@ package database
 // Request will fail if uid doesn't exist
 func (db *appdbimpl) GetUserByID(uid uint64) (User, error) {
 	var user User
-	err := db.c.QueryRow("SELECT * FROM user WHERE uid = ?", uid).Scan(&user.Userid, &user.Username)
+	err := db.c.QueryRow("SELECT uid, username FROM user WHERE uid = ?", uid).Scan(&user.Userid, &user.Username)
 	return user, err
 }
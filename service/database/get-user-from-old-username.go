@@ -0,0 +1,15 @@
+package database
+
+// GetUserByOldUsername allows to get a User database struct passing a username left by the user, matched
+// case-insensitively, so that links and mentions with the old username can be resolved to his account. If more users
+// have left the username, an exact match has precedence, and then the last one who left it. Deleted users are ignored.
+// Request will fail if no user has left the username
+func (db *appdbimpl) GetUserByOldUsername(username string) (User, error) {
+	var user User
+	err := db.c.QueryRow("SELECT user.uid, user.username FROM username_history "+
+		"JOIN user ON user.uid = username_history.uid "+
+		"WHERE username_history.username_key = ? AND user.status NOT IN (?, ?) "+
+		"ORDER BY username_history.username = ? DESC, username_history.released DESC LIMIT 1",
+		usernameKey(username), StatusDeleting, StatusDeleted, username).Scan(&user.Userid, &user.Username)
+	return user, err
+}
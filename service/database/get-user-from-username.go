@@ -1,9 +1,12 @@
 package database
 
-// GetUserByUsername allows to get a User database struct passing a username.
+// GetUserByUsername allows to get a User database struct passing a username, matched case-insensitively. A user with
+// exactly the same username has precedence (only users whose username collides with an older one have no key).
 // Request will fail if username doesn't exist
 func (db *appdbimpl) GetUserByUsername(username string) (User, error) {
 	var user User
-	err := db.c.QueryRow("SELECT * FROM user WHERE username = ?", username).Scan(&user.Userid, &user.Username)
+	err := db.c.QueryRow("SELECT uid, username FROM user WHERE username = ? OR username_key = ? "+
+		"ORDER BY username = ? DESC LIMIT 1", username, usernameKey(username), username).Scan(&user.Userid,
+		&user.Username)
 	return user, err
 }
@@ -0,0 +1,9 @@
+package database
+
+// GetUserRole allows to get the role of a user (RoleUser, RoleModerator or RoleAdmin).
+// Request will fail if uid doesn't exist
+func (db *appdbimpl) GetUserRole(uid uint64) (string, error) {
+	var role string
+	err := db.c.QueryRow("SELECT role FROM user WHERE uid = ?", uid).Scan(&role)
+	return role, err
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// GetUserStatus allows to get the status of a user account at the time `now`. A suspension that ended before `now`
+// is returned as StatusActive.
+// Request will fail if uid doesn't exist
+func (db *appdbimpl) GetUserStatus(uid uint64, now time.Time) (UserStatus, error) {
+	const (
+		statusQuery = "SELECT status, status_reason, status_until, " +
+			"status = '" + StatusSuspended + "' AND status_until IS NOT NULL AND status_until <= ? FROM user WHERE uid = ?"
+	)
+
+	var status UserStatus
+	var until sql.NullString
+	var ended bool
+	err := db.c.QueryRow(statusQuery, databaseTime(now), uid).Scan(&status.Status, &status.Reason, &until, &ended)
+	if err != nil {
+		return UserStatus{}, err
+	}
+
+	if ended {
+		return UserStatus{Status: StatusActive}, nil
+	}
+	status.Until = until.String
+	return status, nil
+}
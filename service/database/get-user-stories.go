@@ -0,0 +1,41 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// GetUserStories allows to get the stories of a user that are not expired at now, in upload order, with the number
+// of users that have seen them.
+func (db *appdbimpl) GetUserStories(uid uint64, now time.Time) ([]Story, error) {
+	const (
+		storiesQuery = "SELECT storyid, uid, format, width, height, timestamp, expiration, " +
+			"(SELECT COUNT(*) FROM story_view WHERE story_view.storyid = story.storyid) " +
+			"FROM story WHERE uid = ? AND expiration > ? ORDER BY timestamp, storyid"
+	)
+
+	rows, err := db.c.Query(storiesQuery, uid, databaseTime(now))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var stories []Story
+	for rows.Next() {
+		var story Story
+		err = rows.Scan(&story.Storyid, &story.Uid, &story.Format, &story.Width, &story.Height, &story.Datetime,
+			&story.Expiration, &story.Views)
+		if err != nil {
+			return stories, err
+		}
+		stories = append(stories, story)
+	}
+
+	if rows.Err() != nil {
+		return stories, rows.Err()
+	}
+
+	return stories, nil
+}
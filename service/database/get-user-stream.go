@@ -2,15 +2,33 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 	"strings"
 )
 
 // GetUserStream allows to get a user Posts stream passing his uid.
+// The stream contains the posts of the followed users and the posts reposted by them, ordered by the time they
+// entered the stream. A repost is skipped if the original post is already in the stream (or it's a post of the user
+// himself) and only the latest repost of each post is kept. Reposts of users banned by the original owner (or that
+// banned him) and of owners banned by the user (or that banned him) are skipped, too. Posts hidden by the moderation
+// and posts of deactivated users are excluded.
 // Request will fail if uid doesn't exist
 func (db *appdbimpl) GetUserStream(uid uint64) ([]Post, error) {
 	const (
-		postsQueryBase     = "SELECT post.postid, post.uid, post.timestamp FROM post WHERE post.uid IN "
-		postOrderQueryBase = ") ORDER BY post.timestamp DESC"
+		postsQueryBase = "WITH stream AS (" +
+			"SELECT post.postid, 0 AS repostid, post.timestamp AS sorttime FROM post WHERE post.uid IN (%[1]s) AND " + postVisible + " " +
+			"UNION ALL " +
+			"SELECT postid, repostid, sorttime FROM (" +
+			"SELECT repost.postid, repost.repostid, repost.timestamp AS sorttime, " +
+			"ROW_NUMBER() OVER (PARTITION BY repost.postid ORDER BY repost.timestamp DESC, repost.repostid DESC) AS rn " +
+			"FROM repost JOIN post ON post.postid = repost.postid " +
+			"WHERE repost.uid IN (%[1]s) AND post.uid NOT IN (%[1]s) AND post.uid != ? AND " + postVisible + " " +
+			"AND post.uid NOT IN (" + inactiveUsers + ") " +
+			"AND NOT EXISTS (SELECT 1 FROM ban WHERE (ban.uid = post.uid AND ban.buid IN (?, repost.uid)) " +
+			"OR (ban.buid = post.uid AND ban.uid IN (?, repost.uid)))" +
+			") WHERE rn = 1) " +
+			"SELECT post.postid, post.uid, post.timestamp, post.caption, stream.repostid " +
+			"FROM stream JOIN post ON post.postid = stream.postid ORDER BY stream.sorttime DESC"
 	)
 
 	// Get the list of followed
@@ -30,10 +48,14 @@ func (db *appdbimpl) GetUserStream(uid uint64) ([]Post, error) {
 	// Add comma between every pair of '?'
 	placeholdersStr := strings.Join(placeholders, ", ")
 
-	// Build final query
-	query := postsQueryBase + "(" + placeholdersStr + postOrderQueryBase
+	// Build final query: the followed list is used for the posts, the reposters and the original owners
+	query := fmt.Sprintf(postsQueryBase, placeholdersStr)
+	args := append([]interface{}{}, values...)
+	args = append(args, values...)
+	args = append(args, values...)
+	args = append(args, uid, uid, uid)
 
-	rows, err := db.c.Query(query, values...)
+	rows, err := db.c.Query(query, args...)
 
 	if err != nil {
 		return nil, err
@@ -47,11 +69,20 @@ func (db *appdbimpl) GetUserStream(uid uint64) ([]Post, error) {
 	for rows.Next() {
 		var post Post
 
-		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime)
+		var repostid uint64
+		err = rows.Scan(&post.Postid, &post.Uid, &post.Datetime, &post.Caption, &repostid)
 		if err != nil {
 			return nil, err
 		}
 
+		// Get the repost that brought the post in the stream
+		if repostid != 0 {
+			post.Repost, err = db.GetRepost(repostid)
+			if err != nil {
+				return posts, err
+			}
+		}
+
 		// Post correctly readed, get number of likes
 		likes, err := db.GetPostLikes(post.Postid)
 		if err != nil {
@@ -65,6 +96,18 @@ func (db *appdbimpl) GetUserStream(uid uint64) ([]Post, error) {
 			return posts, err
 		}
 
+		// Get media
+		post.Media, err = db.GetPostMedia(post.Postid)
+		if err != nil {
+			return posts, err
+		}
+
+		// Get reactions
+		post.Reactions, err = db.GetReactions(post.Postid, 0)
+		if err != nil {
+			return posts, err
+		}
+
 		// Add post to the list
 		posts = append(posts, post)
 	}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// GetUsernameCollisions returns the users whose username collides, except for the case, with the username of an older
+// user (see checkUsernameKeys). Each collision is the user and the one who holds the username.
+func (db *appdbimpl) GetUsernameCollisions() ([]UsernameCollision, error) {
+	rows, err := db.c.Query("SELECT uid, username FROM user WHERE username_key IS NULL ORDER BY uid")
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var collisions []UsernameCollision
+	for rows.Next() {
+		var collision UsernameCollision
+		if err = rows.Scan(&collision.User.Userid, &collision.User.Username); err != nil {
+			return collisions, err
+		}
+		collisions = append(collisions, collision)
+	}
+	if err = rows.Err(); err != nil {
+		return collisions, err
+	}
+
+	// the holder may have changed his username in the meantime: then the username doesn't collide anymore, and the
+	// user gets the key at the next start
+	var found []UsernameCollision
+	for _, collision := range collisions {
+		err = db.c.QueryRow("SELECT uid, username FROM user WHERE username_key = ?",
+			usernameKey(collision.User.Username)).Scan(&collision.Holder.Userid, &collision.Holder.Username)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return found, err
+		}
+		found = append(found, collision)
+	}
+
+	return found, nil
+}
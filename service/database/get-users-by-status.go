@@ -0,0 +1,31 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// GetUsersByStatus allows to get the ids of the users with the specified status, in uid order.
+func (db *appdbimpl) GetUsersByStatus(status string) ([]uint64, error) {
+	rows, err := db.c.Query("SELECT uid FROM user WHERE status = ? ORDER BY uid", status)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var uids []uint64
+	for rows.Next() {
+		var uid uint64
+		if err = rows.Scan(&uid); err != nil {
+			return uids, err
+		}
+		uids = append(uids, uid)
+	}
+
+	if rows.Err() != nil {
+		return uids, rows.Err()
+	}
+
+	return uids, nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ImportPost allows to create a post of a user imported from an archive, with its original upload time (a time in the
+// future is replaced by the current time), its status, its caption and its media in the order of the carousel. A
+// scheduled post whose publish time has passed is published at the next run of the scheduler.
+// If the user has already imported a post with the same key, nothing is created.
+// Function will return the post id and true if the post has been created.
+func (db *appdbimpl) ImportPost(uid uint64, post ImportedPost) (uint64, bool, error) {
+	tx, err := db.c.Begin()
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var postid uint64
+	err = tx.QueryRow("SELECT postid FROM post_import WHERE uid = ? AND importkey = ?", uid, post.Key).Scan(&postid)
+	if err == nil {
+		return postid, false, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return 0, false, err
+	}
+
+	// RECOVER MAX(ID)
+	var maxId uint64
+	err = tx.QueryRow("SELECT MAX(postid) FROM post").Scan(&maxId)
+	if err != nil {
+		maxId = 0
+	}
+	postid = maxId + 1
+
+	var publishAt sql.NullString
+	if post.Status == PostScheduled {
+		publishAt = sql.NullString{String: post.PublishAt, Valid: true}
+	}
+
+	_, err = tx.Exec("INSERT INTO post (postid, uid, timestamp, caption, status, publish_at) "+
+		"VALUES (?, ?, min(?, datetime('now', '+1 hours')), ?, ?, ?)", postid, uid, post.Datetime, post.Caption,
+		post.Status, publishAt)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for position, item := range post.Media {
+		_, err = tx.Exec("INSERT INTO post_media (postid, position, format, width, height) VALUES (?, ?, ?, ?, ?)",
+			postid, position, item.Format, item.Width, item.Height)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	// The hashtags are dated as the post, so that old posts don't make them trend
+	for _, tag := range post.Tags {
+		_, err = tx.Exec("INSERT OR IGNORE INTO post_tag (postid, commentid, tag, timestamp) "+
+			"SELECT postid, 0, ?, timestamp FROM post WHERE postid = ?", tag, postid)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	err = indexPostText(tx, postid, 0, post.Caption)
+	if err != nil {
+		return 0, false, err
+	}
+
+	_, err = tx.Exec("INSERT INTO post_import (uid, importkey, postid) VALUES (?, ?, ?)", uid, post.Key, postid)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return postid, true, tx.Commit()
+}
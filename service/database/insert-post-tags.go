@@ -0,0 +1,29 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// insertPostTags replaces the hashtags of a post caption (commentid = 0) or of a post comment with the specified ones.
+// The hashtags of a caption are dated as the post, so that editing an old caption doesn't make them trend again.
+// The function has to be called inside a transaction, together with the write of the caption or comment.
+func insertPostTags(tx *sql.Tx, postid uint64, commentid uint64, tags []string) error {
+	_, err := tx.Exec("DELETE FROM post_tag WHERE postid = ? AND commentid = ?", postid, commentid)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if commentid == 0 {
+			_, err = tx.Exec("INSERT OR IGNORE INTO post_tag (postid, commentid, tag, timestamp) "+
+				"SELECT postid, 0, ?, timestamp FROM post WHERE postid = ?", tag, postid)
+		} else {
+			_, err = tx.Exec("INSERT OR IGNORE INTO post_tag (postid, commentid, tag, timestamp) VALUES (?, ?, ?, datetime('now', '+1 hours'))", postid, commentid, tag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package database
+
+// LikeComment allows to put a like from uid to a comment under a post, that is the ReactionHeart reaction. It
+// replaces any other reaction of uid to the comment.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) LikeComment(postid uint64, commentid uint64, userid uint64) error {
+	return db.SetReaction(postid, commentid, userid, ReactionHeart)
+}
@@ -0,0 +1,18 @@
+package database
+
+// PinPost allows a user to pin one of his posts to the top of his profile, if he has less than max pinned posts.
+// Pinned posts hidden by the moderation are not counted, as they are not shown on the profile (see GetPinnedPosts).
+// Pinning a post already pinned has no effect.
+// Function will return false if the post is not pinned because the user has already max pinned posts, true otherwise.
+func (db *appdbimpl) PinPost(uid uint64, postid uint64, max uint64) (bool, error) {
+	res, err := db.c.Exec("UPDATE post SET pinned = IFNULL(pinned, (SELECT IFNULL(MAX(pinned), 0) + 1 FROM post WHERE uid = ?)) "+
+		"WHERE postid = ? AND uid = ? AND (pinned IS NOT NULL OR "+
+		"(SELECT COUNT(*) FROM post WHERE uid = ? AND pinned IS NOT NULL AND "+postVisible+") < ?)", uid, postid, uid,
+		uid, max)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
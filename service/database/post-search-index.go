@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+	"unicode"
+)
+
+const (
+	// SnippetStart and SnippetEnd delimit the matched words in the snippets returned by the post search
+	SnippetStart = "\x02"
+	SnippetEnd   = "\x03"
+)
+
+// postSearchDocid returns the id of the search index row of a post caption (commentid = 0) or of a comment.
+// Captions use the opposite of the postid, so that they never collide with the comment ids.
+func postSearchDocid(postid uint64, commentid uint64) int64 {
+	if commentid == 0 {
+		return -int64(postid)
+	}
+	return int64(commentid)
+}
+
+// indexPostText replaces the text of a post caption (commentid = 0) or of a comment in the post search index.
+// The function has to be called inside a transaction, together with the write of the caption or comment.
+func indexPostText(tx *sql.Tx, postid uint64, commentid uint64, text string) error {
+	docid := postSearchDocid(postid, commentid)
+	_, err := tx.Exec("DELETE FROM post_search WHERE docid = ?", docid)
+	if err != nil || strings.TrimSpace(text) == "" {
+		return err
+	}
+
+	_, err = tx.Exec("INSERT INTO post_search (docid, postid, commentid, text) VALUES (?, ?, ?, ?)", docid, postid, commentid, text)
+	return err
+}
+
+// postSearchQuery translates the text searched by a user into a full-text query, so that operators and special
+// characters typed by the user can't make the query fail.
+// Words between double quotes are searched as a phrase, and a word ending with "*" is searched as a prefix. All the
+// words and phrases have to be present. An empty string is returned if there is nothing to search.
+func postSearchQuery(text string) string {
+	var terms []string
+	for i, part := range strings.Split(text, "\"") {
+		words := postSearchWords(part)
+		if len(words) == 0 {
+			continue
+		}
+
+		// Odd parts are between double quotes
+		if i%2 == 1 {
+			terms = append(terms, "\""+strings.Join(words, " ")+"\"")
+			continue
+		}
+		for _, word := range words {
+			terms = append(terms, "\""+word+"\"")
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// postSearchWords splits the text in words made of letters and digits, keeping the "*" at the end of a prefix.
+func postSearchWords(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r) && r != '*'
+	})
+
+	var words []string
+	for _, field := range fields {
+		pieces := strings.FieldsFunc(field, func(r rune) bool {
+			return r == '*'
+		})
+		for j, piece := range pieces {
+			if j == len(pieces)-1 && strings.HasSuffix(field, "*") {
+				piece += "*"
+			}
+			words = append(words, piece)
+		}
+	}
+	return words
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPostSearchDocid(t *testing.T) {
+	tests := []struct {
+		name      string
+		postid    uint64
+		commentid uint64
+		want      int64
+	}{
+		{"caption", 7, 0, -7},
+		{"comment", 7, 3, 3},
+		{"comment of another post", 8, 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postSearchDocid(tt.postid, tt.commentid); got != tt.want {
+				t.Errorf("postSearchDocid(%d, %d) = %d, want %d", tt.postid, tt.commentid, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostSearchWords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"spaces", "  ", nil},
+		{"words", "sunset roma", []string{"sunset", "roma"}},
+		{"punctuation", "sunset, roma!", []string{"sunset", "roma"}},
+		{"operators", "sunset OR -roma NEAR/2", []string{"sunset", "OR", "roma", "NEAR", "2"}},
+		{"prefix", "sun*", []string{"sun*"}},
+		{"star inside a word", "s*un", []string{"s", "un"}},
+		{"lone star", "*", nil},
+		{"combining accent", "café", []string{"café"}},
+		{"non latin", "東京", []string{"東京"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postSearchWords(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postSearchWords(%+q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostSearchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"empty", "", ""},
+		{"only symbols", "-()^:", ""},
+		{"words", "sunset roma", "\"sunset\" \"roma\""},
+		{"operators", "sunset OR roma", "\"sunset\" \"OR\" \"roma\""},
+		{"special characters", "-sunset (roma) col:val", "\"sunset\" \"roma\" \"col\" \"val\""},
+		{"phrase", "\"sunset in roma\"", "\"sunset in roma\""},
+		{"phrase and words", "beach \"sunset roma\" summer", "\"beach\" \"sunset roma\" \"summer\""},
+		{"unterminated phrase", "beach \"sunset roma", "\"beach\" \"sunset roma\""},
+		{"empty phrase", "beach \"\"", "\"beach\""},
+		{"prefix", "sun*", "\"sun*\""},
+		{"prefix in phrase", "\"sunset ro*\"", "\"sunset ro*\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postSearchQuery(tt.text); got != tt.want {
+				t.Errorf("postSearchQuery(%+q) = %+q, want %+q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
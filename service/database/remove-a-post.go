@@ -1,8 +1,85 @@
 package database
 
-// RemovePost allows to remove a specified post if the specified user is the owner.
-// Function will return nil if no errors are present, an error otherwise.
-func (db *appdbimpl) RemovePost(postid uint64, userid uint64) error {
-	_, err := db.c.Exec("DELETE FROM post WHERE postid = ? AND uid = ?", postid, userid)
-	return err
+import "database/sql"
+
+// RemovePost allows to remove a specified post in a single transaction, if the specified user is the owner: its
+// comments (with their hashtags, mentions, reactions, notifications, reports and search index entries), its hashtags,
+// reactions (likes included), notifications, reports, caption in the search index, media, the saves and the reposts of
+// the users are removed, as post ids can be reused. An imported post can be imported again.
+// The media of the post are returned, so that their images can be removed.
+func (db *appdbimpl) RemovePost(postid uint64, userid uint64) ([]Media, error) {
+	const (
+		postComments = "SELECT commentid FROM comment WHERE postid = ?"
+	)
+
+	// The statements are executed in order, as the later ones remove the rows selected by the former ones.
+	// Each placeholder is the postid.
+	statements := []string{
+		// Comments under the post
+		"DELETE FROM mention WHERE commentid IN (" + postComments + ")",
+		"DELETE FROM post_search WHERE docid IN (" + postComments + ")",
+		"DELETE FROM comment WHERE postid = ?",
+
+		// The post, with what references it or its comments
+		"DELETE FROM post_tag WHERE postid = ?",
+		"DELETE FROM reaction WHERE postid = ?",
+		"DELETE FROM notification WHERE postid = ?",
+		"DELETE FROM report WHERE postid = ?",
+		"DELETE FROM post_search WHERE docid IN (SELECT -postid FROM post WHERE postid = ?)",
+		"DELETE FROM saved_post WHERE postid = ?",
+		"DELETE FROM repost WHERE postid = ?",
+		"DELETE FROM post_media WHERE postid = ?",
+		"DELETE FROM post_import WHERE postid = ?",
+		"DELETE FROM post WHERE postid = ?",
+	}
+
+	tx, err := db.c.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var owned int
+	err = tx.QueryRow("SELECT COUNT(*) FROM post WHERE postid = ? AND uid = ?", postid, userid).Scan(&owned)
+	if err != nil || owned == 0 {
+		return nil, err
+	}
+
+	media, err := postMedia(tx, postid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range statements {
+		_, err = tx.Exec(statement, postid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return media, tx.Commit()
+}
+
+// postMedia returns the media of a post.
+func postMedia(tx *sql.Tx, postid uint64) ([]Media, error) {
+	rows, err := tx.Query("SELECT mediaid, postid FROM post_media WHERE postid = ?", postid)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var media []Media
+	for rows.Next() {
+		var item Media
+		if err = rows.Scan(&item.Mediaid, &item.Postid); err != nil {
+			return media, err
+		}
+		media = append(media, item)
+	}
+
+	return media, rows.Err()
 }
@@ -0,0 +1,8 @@
+package database
+
+// RemoveExport allows to remove an export.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) RemoveExport(exportid uint64) error {
+	_, err := db.c.Exec("DELETE FROM export WHERE exportid = ?", exportid)
+	return err
+}
@@ -0,0 +1,8 @@
+package database
+
+// RemoveReaction allows to remove the reaction of uid to a post (commentid = 0) or to one of its comments.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) RemoveReaction(postid uint64, commentid uint64, userid uint64) error {
+	_, err := db.c.Exec("DELETE FROM reaction WHERE postid = ? AND commentid = ? AND uid = ?", postid, commentid, userid)
+	return err
+}
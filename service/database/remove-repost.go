@@ -0,0 +1,8 @@
+package database
+
+// RemoveRepost allows a user to remove his repost of a post. If the user has not reposted the post, nothing happens.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) RemoveRepost(userid uint64, postid uint64) error {
+	_, err := db.c.Exec("DELETE FROM repost WHERE uid = ? AND postid = ?", userid, postid)
+	return err
+}
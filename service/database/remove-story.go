@@ -0,0 +1,25 @@
+package database
+
+// RemoveStory allows to remove a story and its views.
+// Function will return nil if the story is correctly removed, an error otherwise.
+func (db *appdbimpl) RemoveStory(storyid uint64) error {
+	tx, err := db.c.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec("DELETE FROM story_view WHERE storyid = ?", storyid)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM story WHERE storyid = ?", storyid)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,8 @@
+package database
+
+// RenameCollection allows to set a new name for a collection of saved posts.
+// Request will fail if the owner already has a collection with the same name.
+func (db *appdbimpl) RenameCollection(collectionid uint64, name string) error {
+	_, err := db.c.Exec("UPDATE collection SET name = ? WHERE collectionid = ?", name, collectionid)
+	return err
+}
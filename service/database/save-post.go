@@ -0,0 +1,10 @@
+package database
+
+// SavePost allows a user to save a post in one of his collections (collectionid = 0 for no collection).
+// If the post is already saved, it's moved to the specified collection.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) SavePost(uid uint64, postid uint64, collectionid uint64) error {
+	_, err := db.c.Exec("INSERT INTO saved_post (uid, postid, collectionid, timestamp) VALUES (?, ?, ?, datetime('now', '+1 hours')) "+
+		"ON CONFLICT (uid, postid) DO UPDATE SET collectionid = excluded.collectionid", uid, postid, collectionid)
+	return err
+}
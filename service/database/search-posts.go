@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// SearchPosts allows to search the posts whose caption or comments contain the specified text, in reverse
+// chronological order.
+// The text can contain phrases between double quotes and prefixes ending with "*". Each post is returned once, with a
+// snippet of the caption (or of the first comment) that matches: matched words are delimited by SnippetStart and
+// SnippetEnd.
+// Posts of users that have banned uid, or that uid has banned, posts of deactivated users and content hidden by the
+// moderation are excluded.
+// offset and limit select the page of posts to return.
+func (db *appdbimpl) SearchPosts(text string, uid uint64, offset uint64, limit uint64) ([]PostMatch, error) {
+	const (
+		postsQuery = "WITH hit AS MATERIALIZED (SELECT postid, commentid, " +
+			"snippet(post_search, '" + SnippetStart + "', '" + SnippetEnd + "', '…', -1, 12) AS snippet " +
+			"FROM post_search WHERE post_search MATCH ?), " +
+			"best AS (SELECT postid, MIN(commentid) AS commentid FROM hit " +
+			"WHERE commentid NOT IN (SELECT comment.commentid FROM comment WHERE NOT " + commentVisible + ") GROUP BY postid) " +
+			"SELECT post.postid, post.uid, post.timestamp, post.caption, hit.commentid, hit.snippet FROM best " +
+			"JOIN hit ON hit.postid = best.postid AND hit.commentid = best.commentid " +
+			"JOIN post ON post.postid = best.postid " +
+			"WHERE " + postVisible + " AND post.uid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+			"AND post.uid NOT IN (SELECT ban.buid FROM ban WHERE ban.uid = ?) " +
+			"AND post.uid NOT IN (" + inactiveUsers + ") " +
+			"ORDER BY post.timestamp DESC, post.postid DESC LIMIT ? OFFSET ?"
+	)
+
+	var matches []PostMatch
+
+	query := postSearchQuery(text)
+	if query == "" {
+		return matches, nil
+	}
+
+	// Make the query
+	rows, err := db.c.Query(postsQuery, query, uid, uid, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var match PostMatch
+		err = rows.Scan(&match.Post.Postid, &match.Post.Uid, &match.Post.Datetime, &match.Post.Caption, &match.Commentid, &match.Snippet)
+		if err != nil {
+			return nil, err
+		}
+
+		// Post correctly readed, get number of likes
+		likes, err := db.GetPostLikes(match.Post.Postid)
+		if err != nil {
+			return matches, err
+		}
+		match.Post.Likes = uint64(len(likes))
+
+		// Get comments
+		match.Post.Comments, err = db.GetPostComments(match.Post.Postid)
+		if err != nil {
+			return matches, err
+		}
+
+		// Get media
+		match.Post.Media, err = db.GetPostMedia(match.Post.Postid)
+		if err != nil {
+			return matches, err
+		}
+
+		// Get reactions
+		match.Post.Reactions, err = db.GetReactions(match.Post.Postid, 0)
+		if err != nil {
+			return matches, err
+		}
+
+		// Add post to the list
+		matches = append(matches, match)
+	}
+
+	if rows.Err() != nil {
+		return matches, rows.Err()
+	}
+
+	return matches, err
+}
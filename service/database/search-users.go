@@ -2,14 +2,56 @@ package database
 
 import (
 	"database/sql"
+	"strings"
 )
 
-func (db *appdbimpl) SearchUserByUsername(username string) ([]User, error) {
-	const (
-		searchUseranameQuery = "SELECT * FROM user WHERE username LIKE ?"
-	)
+const (
+	// searchMinSimilarity is the minimum trigram similarity of a username with the search text to be returned in the
+	// search results, unless it contains the search text
+	searchMinSimilarity = 0.2
+)
+
+// SearchUserByUsername allows to search users by username on behalf of uid, tolerating typos and matching any part of
+// the username.
+// Usernames are compared using their trigrams: the similarity is the number of shared trigrams divided by the number of
+// distinct trigrams of both. Results are ranked by exact match, similarity, prefix and infix match, and then boosted if
+// uid follows the user or follows some of his followers. An empty search text returns all users, ranked by the boost.
+// The exact, prefix and infix matches compare the search text with the username keys (see usernameKey), so that they
+// are case-insensitive for any letter.
+// Users that have banned uid, deactivated users and deleted users are excluded.
+// offset and limit select the page of users to return.
+func (db *appdbimpl) SearchUserByUsername(username string, uid uint64, offset uint64, limit uint64) ([]User, error) {
+	search := usernameKey(strings.TrimSpace(username))
+	trigrams := usernameTrigrams(search)
+
+	// Select the users sharing at least a trigram with the search text
+	candidatesQuery := "SELECT uid, 0 AS shared FROM user"
+	var args []interface{}
+	if len(trigrams) > 0 {
+		candidatesQuery = "SELECT uid, COUNT(*) AS shared FROM user_trigram " +
+			"WHERE trigram IN (?" + strings.Repeat(", ?", len(trigrams)-1) + ") GROUP BY uid"
+		for _, trigram := range trigrams {
+			args = append(args, trigram)
+		}
+	}
+
+	searchUsernameQuery := "WITH candidate AS (" + candidatesQuery + "), " +
+		"scored AS (SELECT user.uid, user.username, IFNULL(user.username_key, lower(user.username)) AS username_key, " +
+		"1.0 * candidate.shared / (? + (SELECT COUNT(*) FROM user_trigram WHERE user_trigram.uid = user.uid) - candidate.shared) AS similarity, " +
+		"instr(IFNULL(user.username_key, lower(user.username)), ?) AS position, " +
+		"EXISTS (SELECT 1 FROM follow WHERE follow.uid = ? AND follow.fuid = user.uid) AS followed, " +
+		"(SELECT COUNT(*) FROM follow AS mutual WHERE mutual.fuid = user.uid " +
+		"AND mutual.uid IN (SELECT follow.fuid FROM follow WHERE follow.uid = ?)) AS mutuals " +
+		"FROM candidate JOIN user ON user.uid = candidate.uid " +
+		"WHERE user.uid NOT IN (SELECT ban.uid FROM ban WHERE ban.buid = ?) " +
+		"AND user.status NOT IN (" + inactiveStatuses + ")) " +
+		"SELECT uid, username FROM scored WHERE similarity >= ? OR position > 0 " +
+		"ORDER BY username_key = ? DESC, " +
+		"similarity + (position = 1) * 0.5 + (position > 1) * 0.25 + followed * 0.3 + min(mutuals, 5) * 0.05 DESC, " +
+		"username LIMIT ? OFFSET ?"
+	args = append(args, len(trigrams), search, uid, uid, uid, searchMinSimilarity, search, limit, offset)
 
-	rows, err := db.c.Query(searchUseranameQuery, username+"%")
+	rows, err := db.c.Query(searchUsernameQuery, args...)
 	if err != nil {
 		return nil, err
 	}
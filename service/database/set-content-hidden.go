@@ -0,0 +1,12 @@
+package database
+
+// SetContentHidden allows to hide (or to show again) a post (if commentid is 0) or a comment to the users.
+func (db *appdbimpl) SetContentHidden(postid uint64, commentid uint64, hidden bool) error {
+	var err error
+	if commentid == 0 {
+		_, err = db.c.Exec("UPDATE post SET hidden = ? WHERE postid = ?", hidden, postid)
+	} else {
+		_, err = db.c.Exec("UPDATE comment SET hidden = ? WHERE commentid = ? AND postid = ?", hidden, commentid, postid)
+	}
+	return err
+}
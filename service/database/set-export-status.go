@@ -0,0 +1,11 @@
+package database
+
+import "time"
+
+// SetExportStatus allows to change the status of an export, together with the secret token of its download link and
+// the time when the archive expires and it's removed.
+func (db *appdbimpl) SetExportStatus(exportid uint64, status string, token string, expiration time.Time) error {
+	_, err := db.c.Exec("UPDATE export SET status = ?, token = ?, expiration = ? WHERE exportid = ?", status, token,
+		databaseTime(expiration), exportid)
+	return err
+}
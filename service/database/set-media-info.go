@@ -0,0 +1,9 @@
+package database
+
+// SetMediaInfo allows to set the format and the size of a media.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) SetMediaInfo(media Media) error {
+	_, err := db.c.Exec("UPDATE post_media SET format = ?, width = ?, height = ? WHERE mediaid = ?",
+		media.Format, media.Width, media.Height, media.Mediaid)
+	return err
+}
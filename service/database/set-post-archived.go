@@ -0,0 +1,14 @@
+package database
+
+// SetPostArchived allows to archive a post, so that it's shown only to its owner, or to restore it. Archiving a post
+// removes it from the pinned posts of its owner; its likes and comments are kept.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) SetPostArchived(postid uint64, archived bool) error {
+	var err error
+	if archived {
+		_, err = db.c.Exec("UPDATE post SET archived = 1, pinned = NULL WHERE postid = ?", postid)
+	} else {
+		_, err = db.c.Exec("UPDATE post SET archived = 0 WHERE postid = ?", postid)
+	}
+	return err
+}
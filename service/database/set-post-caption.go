@@ -0,0 +1,31 @@
+package database
+
+// SetPostCaption allows to set (or edit) the caption of a post, re-indexing the hashtags it contains
+// and its text for the search.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) SetPostCaption(postid uint64, caption string, tags []string) error {
+	tx, err := db.c.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec("UPDATE post SET caption = ? WHERE postid = ?", caption, postid)
+	if err != nil {
+		return err
+	}
+
+	err = insertPostTags(tx, postid, 0, tags)
+	if err != nil {
+		return err
+	}
+
+	err = indexPostText(tx, postid, 0, caption)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
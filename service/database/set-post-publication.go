@@ -0,0 +1,44 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SetPostPublication allows to change the status of a post:
+//   - PostPublished publishes the post at the time `at`, that becomes its upload time (its hashtags too), so that it
+//     appears in the streams as a new post
+//   - PostScheduled schedules the post to be published at the time `at`
+//   - PostDraft turns the post back into a draft
+//
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) SetPostPublication(postid uint64, status string, at time.Time) error {
+	tx, err := db.c.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	switch status {
+	case PostPublished:
+		_, err = tx.Exec("UPDATE post SET status = ?, publish_at = NULL, timestamp = ? WHERE postid = ?",
+			status, databaseTime(at), postid)
+		if err == nil {
+			_, err = tx.Exec("UPDATE post_tag SET timestamp = ? WHERE postid = ? AND commentid = 0",
+				databaseTime(at), postid)
+		}
+	default:
+		var publishAt sql.NullString
+		if status == PostScheduled {
+			publishAt = sql.NullString{String: databaseTime(at), Valid: true}
+		}
+		_, err = tx.Exec("UPDATE post SET status = ?, publish_at = ? WHERE postid = ?", status, publishAt, postid)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
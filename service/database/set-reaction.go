@@ -0,0 +1,11 @@
+package database
+
+// SetReaction allows uid to react to a post (commentid = 0) or to one of its comments. If uid has already reacted,
+// the reaction is replaced.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) SetReaction(postid uint64, commentid uint64, userid uint64, reaction string) error {
+	_, err := db.c.Exec("INSERT INTO reaction (uid, postid, commentid, type, timestamp) VALUES (?, ?, ?, ?, datetime('now', '+1 hours')) "+
+		"ON CONFLICT (uid, postid, commentid) DO UPDATE SET type = excluded.type, timestamp = excluded.timestamp "+
+		"WHERE type != excluded.type", userid, postid, commentid, reaction)
+	return err
+}
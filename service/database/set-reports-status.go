@@ -0,0 +1,9 @@
+package database
+
+// SetReportsStatus allows to close all the open reports of a post (if commentid is 0) or of a comment with the
+// status ReportResolved or ReportDismissed.
+func (db *appdbimpl) SetReportsStatus(postid uint64, commentid uint64, status string) error {
+	_, err := db.c.Exec("UPDATE report SET status = ? WHERE postid = ? AND commentid = ? AND status = ?", status, postid,
+		commentid, ReportOpen)
+	return err
+}
@@ -0,0 +1,20 @@
+package database
+
+// SetRepost allows a user to repost a post with an optional quote. If the user has already reposted the post, only
+// the quote is changed.
+// Function will return the repost.
+func (db *appdbimpl) SetRepost(userid uint64, postid uint64, quote string) (Repost, error) {
+	_, err := db.c.Exec("INSERT INTO repost (uid, postid, quote, timestamp) VALUES (?, ?, ?, datetime('now', '+1 hours')) "+
+		"ON CONFLICT (uid, postid) DO UPDATE SET quote = excluded.quote", userid, postid, quote)
+	if err != nil {
+		return Repost{}, err
+	}
+
+	var repostid uint64
+	err = db.c.QueryRow("SELECT repostid FROM repost WHERE uid = ? AND postid = ?", userid, postid).Scan(&repostid)
+	if err != nil {
+		return Repost{}, err
+	}
+
+	return db.GetRepost(repostid)
+}
@@ -0,0 +1,10 @@
+package database
+
+import "time"
+
+// SetStorySeen allows to record that uid has seen a story at now. If uid has already seen the story, nothing happens.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) SetStorySeen(storyid uint64, uid uint64, now time.Time) error {
+	_, err := db.c.Exec("INSERT OR IGNORE INTO story_view (storyid, uid, timestamp) VALUES (?, ?, ?)", storyid, uid, databaseTime(now))
+	return err
+}
@@ -0,0 +1,7 @@
+package database
+
+// SetUserRole allows to change the role of a user (RoleUser, RoleModerator or RoleAdmin).
+func (db *appdbimpl) SetUserRole(uid uint64, role string) error {
+	_, err := db.c.Exec("UPDATE user SET role = ? WHERE uid = ?", role, uid)
+	return err
+}
@@ -0,0 +1,18 @@
+package database
+
+import (
+	"time"
+)
+
+// SetUserStatus allows to change the status of a user account with a reason. until is the end of the status (e.g., of
+// a suspension); the zero time means that the status has no end.
+func (db *appdbimpl) SetUserStatus(uid uint64, status string, reason string, until time.Time) error {
+	var statusUntil interface{}
+	if !until.IsZero() {
+		statusUntil = databaseTime(until)
+	}
+
+	_, err := db.c.Exec("UPDATE user SET status = ?, status_reason = ?, status_until = ? WHERE uid = ?", status, reason,
+		statusUntil, uid)
+	return err
+}
@@ -1,8 +1,43 @@
 package database
 
-// SetUsername allows to set a new username for a specified uid.
-// Request will fail if username already exists in database
-func (db *appdbimpl) SetUsername(userid uint64, newUsername string) error {
-	_, err := db.c.Exec("UPDATE user SET username = ? WHERE uid = ?", newUsername, userid)
-	return err
+import "time"
+
+// SetUsername allows to set a new username (and its skeleton) for a specified uid, re-indexing it for the search.
+// The old username is added to the username history, released at now.
+// Request will fail if username (even with a different case) or skeleton already exists in database
+func (db *appdbimpl) SetUsername(userid uint64, newUsername string, skeleton string, now time.Time) error {
+	tx, err := db.c.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var oldUsername, oldSkeleton string
+	err = tx.QueryRow("SELECT username, skeleton FROM user WHERE uid = ?", userid).Scan(&oldUsername, &oldSkeleton)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE user SET username = ?, skeleton = ?, username_key = ? WHERE uid = ?", newUsername, skeleton,
+		usernameKey(newUsername), userid)
+	if err != nil {
+		return err
+	}
+
+	if oldUsername != newUsername {
+		_, err = tx.Exec("INSERT INTO username_history (uid, username, skeleton, username_key, released) "+
+			"VALUES (?, ?, ?, ?, ?)", userid, oldUsername, oldSkeleton, usernameKey(oldUsername), databaseTime(now))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = indexUsername(tx, userid, newUsername)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,10 @@
+package database
+
+// UnlikeComment allows to remove the like (the ReactionHeart reaction) of uid from a comment under a post. Other
+// reactions are not removed.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) UnlikeComment(postid uint64, commentid uint64, userid uint64) error {
+	_, err := db.c.Exec("DELETE FROM reaction WHERE postid = ? AND commentid = ? AND uid = ? AND type = ?",
+		postid, commentid, userid, ReactionHeart)
+	return err
+}
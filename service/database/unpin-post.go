@@ -0,0 +1,8 @@
+package database
+
+// UnpinPost allows a user to remove one of his posts from the pinned posts of his profile.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) UnpinPost(uid uint64, postid uint64) error {
+	_, err := db.c.Exec("UPDATE post SET pinned = NULL WHERE postid = ? AND uid = ?", postid, uid)
+	return err
+}
@@ -0,0 +1,8 @@
+package database
+
+// UnsavePost allows a user to remove a post from his saved posts.
+// Function will return nil if no errors are present, an error otherwise.
+func (db *appdbimpl) UnsavePost(uid uint64, postid uint64) error {
+	_, err := db.c.Exec("DELETE FROM saved_post WHERE uid = ? AND postid = ?", uid, postid)
+	return err
+}
@@ -0,0 +1,9 @@
+package database
+
+import "strings"
+
+// usernameKey returns the key of a username, used to compare usernames case-insensitively: two usernames with the
+// same key are the same username, so only one of them may exist. Usernames are already in NFC form.
+func usernameKey(username string) string {
+	return strings.ToLower(username)
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps characters that are visually identical (or nearly) to a Latin letter or digit to their Latin
+// prototype. It is a subset of the Unicode confusables list (UTS #39) covering the Cyrillic, Greek and Armenian
+// look-alikes that are commonly used to impersonate other users.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'А': 'A', 'В': 'B', 'с': 'c', 'С': 'C', 'ԁ': 'd', 'е': 'e', 'Е': 'E', 'һ': 'h', 'Н': 'H',
+	'і': 'i', 'І': 'I', 'ј': 'j', 'Ј': 'J', 'К': 'K', 'ӏ': 'l', 'Ӏ': 'I', 'М': 'M', 'о': 'o', 'О': 'O',
+	'р': 'p', 'Р': 'P', 'ԛ': 'q', 'ѕ': 's', 'Ѕ': 'S', 'Т': 'T', 'ս': 'u', 'ѵ': 'v', 'ԝ': 'w', 'х': 'x',
+	'Х': 'X', 'у': 'y', 'Ү': 'Y', 'З': '3', 'ь': 'b',
+	// Greek
+	'α': 'a', 'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'ι': 'i', 'Ι': 'I', 'Κ': 'K', 'κ': 'k',
+	'Μ': 'M', 'Ν': 'N', 'ν': 'v', 'ο': 'o', 'Ο': 'O', 'ρ': 'p', 'Ρ': 'P', 'Τ': 'T', 'υ': 'u', 'Υ': 'Y',
+	'χ': 'x', 'Χ': 'X',
+	// Armenian
+	'օ': 'o', 'Օ': 'O', 'ց': 'g', 'հ': 'h', 'ո': 'n',
+}
+
+// UsernameSkeleton returns the "skeleton" of a username: its key (see usernameKey) in which every character that
+// looks like a Latin letter or digit is replaced by it, in lowercase. Two usernames with the same skeleton are
+// visually confusable (e.g., Cyrillic "СОРЕ" and Latin "cope"), so only one of them may exist.
+func UsernameSkeleton(username string) string {
+	var skeleton strings.Builder
+	for _, r := range norm.NFKD.String(usernameKey(username)) {
+		if prototype, ok := confusables[r]; ok {
+			r = prototype
+		}
+		// compatibility characters (e.g., mathematical letters) may decompose to uppercase letters
+		skeleton.WriteRune(unicode.ToLower(r))
+	}
+	return norm.NFC.String(skeleton.String())
+}
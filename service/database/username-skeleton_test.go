@@ -0,0 +1,31 @@
+package database
+
+import "testing"
+
+func TestUsernameSkeleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     string
+	}{
+		{"latin", "simone", "simone"},
+		{"uppercase", "Simone", "simone"},
+		{"uppercase cyrillic look-alikes", "СОРЕ", "cope"},
+		{"mixed case look-alikes", "РayPal", "paypal"},
+		{"mathematical letters", "𝐒imone", "simone"},
+		{"cyrillic look-alikes", "раураl", "paypal"},
+		{"greek look-alikes", "ορα", "opa"},
+		{"armenian look-alikes", "գօօգlե", "գooգlե"},
+		{"compatibility characters", "ｓｉｍｏｎｅ", "simone"},
+		{"accent kept", "café", "café"},
+		{"no look-alike", "павел", "пaвeл"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UsernameSkeleton(tt.username); got != tt.want {
+				t.Errorf("UsernameSkeleton(%+q) = %+q, want %+q", tt.username, got, tt.want)
+			}
+		})
+	}
+}
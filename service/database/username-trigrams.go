@@ -0,0 +1,45 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// usernameTrigrams returns the distinct trigrams (sequences of three characters) of the lower case username. The
+// username is padded with two spaces at the beginning and one at the end, so that short usernames have trigrams too and
+// prefixes weigh more than the rest of the username.
+func usernameTrigrams(username string) []string {
+	runes := []rune("  " + strings.ToLower(strings.TrimSpace(username)) + " ")
+	if len(runes) <= 3 {
+		return nil
+	}
+
+	var trigrams []string
+	seen := map[string]bool{}
+	for i := 0; i+3 <= len(runes); i++ {
+		trigram := string(runes[i : i+3])
+		if !seen[trigram] {
+			seen[trigram] = true
+			trigrams = append(trigrams, trigram)
+		}
+	}
+	return trigrams
+}
+
+// indexUsername replaces the trigrams of the user in the search index with the ones of the specified username.
+// The function has to be called inside a transaction, together with the write of the username.
+func indexUsername(tx *sql.Tx, uid uint64, username string) error {
+	_, err := tx.Exec("DELETE FROM user_trigram WHERE uid = ?", uid)
+	if err != nil {
+		return err
+	}
+
+	for _, trigram := range usernameTrigrams(username) {
+		_, err = tx.Exec("INSERT OR IGNORE INTO user_trigram (trigram, uid) VALUES (?, ?)", trigram, uid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}